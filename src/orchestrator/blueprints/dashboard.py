@@ -21,7 +21,7 @@ from flask import (
 from ..extensions import csrf
 from common.services.logging import FileLogger
 from ..services.rotation import RotationService
-from .utils import _settings, get_client_ip, login_required
+from .utils import _settings, get_client_ip, internal_token_required, login_required
 
 
 dashboard_bp = Blueprint("dashboard", __name__, url_prefix="/orchestrator")
@@ -164,20 +164,40 @@ def api_control():
         )
 
     if action == "rotate":
-        success, message, snapshot = rotation.force_rotate()
-        status_code = 200 if success else 409
-        response = {
-            "status": "ok" if success else "error",
-            "message": message,
-            "rotation_active": rotation.rotation_active,
-        }
-        if snapshot:
-            response["snapshot"] = snapshot
-        return jsonify(response), status_code
+        return _force_rotate_response(rotation)
 
     return jsonify({"status": "error", "message": f"Unknown action '{action}'"}), 400
 
 
+@dashboard_bp.route("/api/internal/rotate", methods=["POST"])
+@csrf.exempt
+@internal_token_required
+def api_internal_rotate():
+    """Force a rotation on behalf of ``leyzenctl rotate``."""
+    _logger().log(
+        "[CONTROL] Received action",
+        context={
+            "action": "rotate",
+            "client_ip": get_client_ip(),
+            "source": "internal",
+        },
+    )
+    return _force_rotate_response(_rotation_service())
+
+
+def _force_rotate_response(rotation: RotationService):
+    success, message, snapshot = rotation.force_rotate()
+    status_code = 200 if success else 409
+    response = {
+        "status": "ok" if success else "error",
+        "message": message,
+        "rotation_active": rotation.rotation_active,
+    }
+    if snapshot:
+        response["snapshot"] = snapshot
+    return jsonify(response), status_code
+
+
 @dashboard_bp.route("/logs", strict_slashes=False)
 @login_required
 def logs():
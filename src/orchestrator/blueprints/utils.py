@@ -2,10 +2,11 @@
 
 from __future__ import annotations
 
+import hmac
 from functools import wraps
 from typing import Callable, TypeVar
 
-from flask import current_app, redirect, request, session, url_for
+from flask import current_app, jsonify, redirect, request, session, url_for
 
 from common.utils import get_client_ip as _get_client_ip_base
 from ..config import Settings
@@ -72,4 +73,37 @@ def login_required(view: F) -> F:
     return decorated  # type: ignore[return-value]
 
 
-__all__ = ["_settings", "get_client_ip", "login_required"]
+def internal_token_required(view: F) -> F:
+    """Decorator for endpoints called by tooling instead of a logged-in user.
+
+    Requests must carry ``Authorization: Bearer <INTERNAL_API_TOKEN>``, the same
+    token the vault internal API accepts (explicitly set or derived from SECRET_KEY).
+    """
+
+    @wraps(view)
+    def decorated(*args, **kwargs):
+        auth_header = request.headers.get("Authorization", "")
+        token = ""
+        if auth_header.startswith("Bearer "):
+            token = auth_header[len("Bearer ") :].strip()
+
+        expected_token = _settings().internal_api_token
+        if not token or not expected_token or not hmac.compare_digest(
+            token, expected_token
+        ):
+            current_app.config["LOGGER"].log(
+                "[INTERNAL API] Access denied",
+                context={"path": request.path, "client_ip": get_client_ip()},
+            )
+            return jsonify({"status": "error", "message": "Unauthorized"}), 401
+        return view(*args, **kwargs)
+
+    return decorated  # type: ignore[return-value]
+
+
+__all__ = [
+    "_settings",
+    "get_client_ip",
+    "internal_token_required",
+    "login_required",
+]
@@ -593,6 +593,7 @@ class DatabaseBackupService:
         created_at: datetime,
         s3_location: str | None = None,
         encryption_method: str | None = None,
+        completed_at: datetime | None = None,
     ) -> Path:
         """Write backup metadata.json file to storage.
 
@@ -607,6 +608,7 @@ class DatabaseBackupService:
             storage_type: Storage type ("local", "s3", or "both")
             created_at: Backup creation timestamp
             s3_location: Optional S3 location if stored on S3
+            completed_at: When the dump was written, encrypted and validated
 
         Returns:
             Path to metadata.json file
@@ -647,6 +649,11 @@ class DatabaseBackupService:
                 "validated_at": datetime.now(timezone.utc).isoformat(),
                 "method": "pg_restore_list",
             },
+            # Dump, encryption and validation; uploads to S3 are not included
+            "timing": {
+                "started_at": created_at.isoformat(),
+                "completed_at": completed_at.isoformat() if completed_at else None,
+            },
         }
 
         # Add encryption information if backup is encrypted
@@ -1364,6 +1371,7 @@ class DatabaseBackupService:
                 raise RuntimeError(
                     f"Backup validation failed: {validation_result.get('error')}"
                 )
+            completed_at = datetime.now(timezone.utc)
 
             # Write metadata.json to storage (storage-first)
             metadata_path = self._write_backup_metadata(
@@ -1378,6 +1386,7 @@ class DatabaseBackupService:
                 created_at=created_at,
                 s3_location=s3_location,
                 encryption_method=encryption_method,
+                completed_at=completed_at,
             )
 
             # Handle storage based on storage_type
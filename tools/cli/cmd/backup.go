@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+)
+
+func init() {
+	var toS3 bool
+
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Create a database backup",
+		Long: "Runs a manual database backup inside a healthy vault container and streams its progress.\n" +
+			"Use --s3 to also upload the backup to the configured external storage.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := internal.EnsureDockerGeneratedFileWithWriter(cmd.OutOrStdout(), cmd.ErrOrStderr(), EnvFilePath()); err != nil {
+				return fmt.Errorf("failed to initialize configuration: %w", err)
+			}
+
+			if internal.DryRun() {
+				storage := "local storage"
+				if toS3 {
+					storage = "local and external storage"
+				}
+				color.HiYellow("[dry-run] Would create a database backup in a healthy vault container (%s)", storage)
+				return nil
+			}
+
+			color.HiCyan("Starting database backup...")
+			if err := internal.CreateBackup(EnvFilePath(), toS3, os.Stdout, os.Stderr); err != nil {
+				return fmt.Errorf("failed to create backup: %w", err)
+			}
+			color.HiGreen("Backup completed successfully")
+			return nil
+		},
+	}
+	backupCmd.Flags().BoolVar(&toS3, "s3", false, "Also push the backup to external (S3) storage")
+
+	rootCmd.AddCommand(backupCmd)
+}
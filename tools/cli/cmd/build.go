@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"leyzenctl/internal"
@@ -17,33 +16,37 @@ func init() {
 		Args:         cobra.ArbitraryArgs,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newLifecycleOutput("build")
 			if len(args) > 0 {
-				color.HiCyan("Rebuilding services: %s...", strings.Join(args, ", "))
+				out.Info("Rebuilding services: %s...", strings.Join(args, ", "))
 				// Always regenerate configuration to ensure latest changes are applied
-				if err := internal.RunBuildScript(EnvFilePath()); err != nil {
-					return fmt.Errorf("failed to generate configuration: %w", err)
+				if err := out.PrepareConfig(false); err != nil {
+					return err
+				}
+				if err := internal.ValidateServiceNames(EnvFilePath(), args); err != nil {
+					return err
 				}
 				composeArgs := append([]string{"up", "-d", "--build", "--remove-orphans"}, args...)
-				if err := internal.RunCompose(EnvFilePath(), composeArgs...); err != nil {
+				if err := out.Compose(composeArgs...); err != nil {
 					return fmt.Errorf("failed to rebuild services: %w", err)
 				}
-				color.HiGreen("✓ Successfully rebuilt services")
+				out.Success("✓ Successfully rebuilt services")
 				return nil
 			}
 
 			// Stop containers before building
-			color.HiYellow("Stopping Docker stack...")
-			if err := internal.RunCompose(EnvFilePath(), "down", "--remove-orphans"); err != nil {
+			out.Notice("Stopping Docker stack...")
+			if err := out.Compose("down", "--remove-orphans"); err != nil {
 				return fmt.Errorf("failed to stop stack: %w", err)
 			}
-			if err := internal.RunBuildScript(EnvFilePath()); err != nil {
-				return fmt.Errorf("failed to build configuration: %w", err)
+			if err := out.PrepareConfig(false); err != nil {
+				return err
 			}
-			color.HiCyan("Rebuilding Docker stack...")
-			if err := internal.RunCompose(EnvFilePath(), "up", "-d", "--build", "--remove-orphans"); err != nil {
+			out.Info("Rebuilding Docker stack...")
+			if err := out.Compose("up", "-d", "--build", "--remove-orphans"); err != nil {
 				return fmt.Errorf("failed to rebuild stack: %w", err)
 			}
-			color.HiGreen("✓ Successfully rebuilt Docker stack")
+			out.Success("✓ Successfully rebuilt Docker stack")
 			return nil
 		},
 	}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 	"unicode/utf8"
@@ -91,51 +93,154 @@ func init() {
 		},
 	}
 
+	var generate bool
+	var setPairs []string
+	var setFile string
 	setCmd := &cobra.Command{
-		Use:          "set <KEY> <VALUE>",
-		Short:        "Set an environment variable",
-		Args:         cobra.ExactArgs(2),
+		Use:   "set [<KEY> <VALUE>]",
+		Short: "Set one or more environment variables",
+		Long: "Sets KEY to VALUE in the env file and regenerates the configuration.\n" +
+			"Use --generate instead of VALUE to store a random 64-character hex secret.\n" +
+			"To change several variables at once, repeat --set KEY=VALUE or pass --file with a dotenv\n" +
+			"or JSON file: every value is validated first, nothing is written if one is rejected,\n" +
+			"and the configuration is regenerated once.\n" +
+			"A variable defined more than once keeps only its first definition once it is set;\n" +
+			"other duplicated variables are left as they are and reported as warnings.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(setPairs) > 0 || setFile != "" {
+				if generate {
+					return fmt.Errorf("--generate cannot be combined with --set or --file")
+				}
+				return cobra.NoArgs(cmd, args)
+			}
+			if generate {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.ExactArgs(2)(cmd, args)
+		},
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			key := args[0]
-			rawValue := args[1]
+			var pairs []importedPair
+			switch {
+			case generate:
+				secret, err := internal.GenerateSecret()
+				if err != nil {
+					return err
+				}
+				pairs = append(pairs, importedPair{Key: args[0], Value: secret})
+			case len(args) == 2:
+				pairs = append(pairs, importedPair{Key: args[0], Value: args[1]})
+			default:
+				if setFile != "" {
+					filePairs, err := readImportFile(setFile)
+					if err != nil {
+						return err
+					}
+					pairs = append(pairs, filePairs...)
+				}
+				// --set comes after --file so it can override a value of the file
+				for _, raw := range setPairs {
+					key, value, ok := strings.Cut(raw, "=")
+					if !ok || strings.TrimSpace(key) == "" {
+						return fmt.Errorf("invalid --set %q (expected KEY=VALUE)", raw)
+					}
+					pairs = append(pairs, importedPair{Key: strings.TrimSpace(key), Value: value})
+				}
+			}
 
-			sanitized, err := internal.ValidateEnvValue(key, rawValue)
-			if err != nil {
-				return err
+			if len(pairs) == 0 {
+				return fmt.Errorf("no variables to set in %s", setFile)
+			}
+
+			// Validate everything before touching the file so a rejected value never
+			// leaves the configuration half-applied
+			var rejected []string
+			for i, pair := range pairs {
+				sanitized, err := internal.ValidateEnvValue(EnvFilePath(), pair.Key, pair.Value)
+				if err != nil {
+					rejected = append(rejected, fmt.Sprintf("%s: %v", pair.Key, err))
+					continue
+				}
+				pairs[i].Value = sanitized
+			}
+			if len(rejected) > 0 {
+				return fmt.Errorf("no changes written, invalid values:\n  %s", strings.Join(rejected, "\n  "))
 			}
 
 			envFile, err := internal.LoadEnvFile(EnvFilePath())
 			if err != nil {
 				return err
 			}
-			envFile.Set(key, sanitized)
-			if err := envFile.Write(); err != nil {
+			for _, pair := range pairs {
+				envFile.Set(pair.Key, pair.Value)
+			}
+			if err := internal.ValidateSizeLimits(envFile.Pairs()); err != nil {
+				return err
+			}
+			if err := envFile.WriteWithBackup(); err != nil {
 				return err
 			}
+			for _, key := range envFile.Duplicates() {
+				color.HiYellow("[WARN] %s is defined more than once (last value wins)", key)
+			}
 
 			if err := internal.RunBuildScript(EnvFilePath()); err != nil {
 				fmt.Println("[WARN] Failed to rebuild configuration:", err)
 			}
 
-			color.HiGreen("%s updated", key)
+			if len(pairs) == 1 {
+				color.HiGreen("%s updated", pairs[0].Key)
+			} else {
+				color.HiGreen("Updated %d variables", len(pairs))
+			}
 			return nil
 		},
 	}
+	setCmd.Flags().BoolVar(&generate, "generate", false, "Generate a random secret instead of passing VALUE")
+	setCmd.Flags().StringArrayVar(&setPairs, "set", nil, "Set KEY=VALUE (repeatable)")
+	setCmd.Flags().StringVar(&setFile, "file", "", "Read the variables to set from a dotenv or JSON file")
 
+	var showDiff bool
 	generateCmd := &cobra.Command{
-		Use:          "generate",
-		Short:        "Generate Docker Compose and HAProxy configuration files",
-		Long:         "Regenerates docker-generated.yml and haproxy.cfg based on the current .env configuration without starting the stack.",
+		Use:   "generate",
+		Short: "Generate Docker Compose and HAProxy configuration files",
+		Long: "Regenerates docker-generated.yml and haproxy.cfg based on the current .env configuration without starting the stack.\n" +
+			"With the global --dry-run the compose YAML is printed instead of written; --diff previews the changes against the existing file.",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := internal.RunBuildScript(EnvFilePath()); err != nil {
+			if !internal.DryRun() && !showDiff {
+				if err := internal.RunBuildScript(EnvFilePath()); err != nil {
+					return fmt.Errorf("failed to generate configuration: %w", err)
+				}
+				color.HiGreen("Configuration generated successfully")
+				return nil
+			}
+
+			// Previews keep stdout for the YAML or diff; progress goes to stderr
+			generated, err := internal.RenderConfig(cmd.ErrOrStderr(), EnvFilePath())
+			if err != nil {
 				return fmt.Errorf("failed to generate configuration: %w", err)
 			}
-			color.HiGreen("Configuration generated successfully")
+
+			if showDiff {
+				existing, err := os.ReadFile(generated.ComposePath)
+				if err != nil && !errors.Is(err, os.ErrNotExist) {
+					return fmt.Errorf("failed to read %s: %w", generated.ComposePath, err)
+				}
+				diff := internal.UnifiedDiff("docker-generated.yml (current)", "docker-generated.yml (generated)", string(existing), string(generated.Compose))
+				if diff == "" {
+					color.HiGreen("docker-generated.yml is up to date")
+					return nil
+				}
+				fmt.Fprint(cmd.OutOrStdout(), internal.ColorizeDiff(diff))
+				return nil
+			}
+
+			fmt.Fprint(cmd.OutOrStdout(), string(generated.Compose))
 			return nil
 		},
 	}
+	generateCmd.Flags().BoolVar(&showDiff, "diff", false, "Show a unified diff against the existing docker-generated.yml without writing any file")
 
 	configCmd.AddCommand(listCmd, setCmd, generateCmd)
 }
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+)
+
+func init() {
+	var addMissing bool
+
+	diffTemplateCmd := &cobra.Command{
+		Use:   "diff-template",
+		Short: "Compare the env file with env.template",
+		Long: "Lists the variables of env.template missing from the env file, the variables of the env file\n" +
+			"that are not in the template, and the variables still set to the template default.\n" +
+			"Use --add-missing to append the missing variables with their template defaults.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			template, err := internal.LoadEnvTemplate(EnvFilePath())
+			if err != nil {
+				return err
+			}
+			if len(template) == 0 {
+				return fmt.Errorf("env.template not found next to %s", EnvFilePath())
+			}
+			envFile, err := internal.LoadEnvFile(EnvFilePath())
+			if err != nil {
+				return err
+			}
+			env := envFile.Pairs()
+
+			var missing, extra, defaults []string
+			for key, value := range template {
+				current, ok := env[key]
+				switch {
+				case !ok:
+					missing = append(missing, key)
+				case value != "" && current == value:
+					defaults = append(defaults, key)
+				}
+			}
+			// Optional variables are commented out in the template but still documented there
+			docs, err := internal.LoadEnvDocumentation(EnvFilePath())
+			if err != nil {
+				return err
+			}
+			for key := range env {
+				_, inTemplate := template[key]
+				_, documented := docs[key]
+				if !inTemplate && !documented {
+					extra = append(extra, key)
+				}
+			}
+			sort.Strings(missing)
+			sort.Strings(extra)
+			sort.Strings(defaults)
+
+			if len(missing)+len(extra)+len(defaults) == 0 {
+				color.HiGreen("%s matches env.template", EnvFilePath())
+				return nil
+			}
+
+			printKeys := func(title string, keys []string, value func(string) string) {
+				if len(keys) == 0 {
+					return
+				}
+				color.HiCyan("%s (%d):", title, len(keys))
+				for _, key := range keys {
+					fmt.Printf("  %s%s\n", key, value(key))
+				}
+			}
+			templateValue := func(key string) string {
+				if template[key] == "" || internal.IsSecretKey(key) {
+					return ""
+				}
+				return "=" + template[key]
+			}
+			printKeys("Missing from the env file", missing, templateValue)
+			printKeys("Not in env.template", extra, func(string) string { return "" })
+			printKeys("Still set to the template default", defaults, templateValue)
+
+			if !addMissing || len(missing) == 0 {
+				return nil
+			}
+			for _, key := range missing {
+				envFile.Set(key, template[key])
+			}
+			if err := envFile.WriteWithBackup(); err != nil {
+				return err
+			}
+			color.HiGreen("Added %d missing variable(s) to %s", len(missing), EnvFilePath())
+			return nil
+		},
+	}
+	diffTemplateCmd.Flags().BoolVar(&addMissing, "add-missing", false, "Append the missing variables with their template defaults")
+
+	configCmd.AddCommand(diffTemplateCmd)
+}
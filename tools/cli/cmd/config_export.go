@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+)
+
+const redactedValue = "********"
+
+func init() {
+	var format string
+	var redact bool
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the resolved configuration as JSON or shell variables",
+		Long: "Prints every variable from env.template merged with the current .env file, so other tools can consume it.\n" +
+			"Use --format shell for `export KEY='VALUE'` lines and --redact to mask passwords, secrets and tokens.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pairs, err := internal.LoadAllEnvVariables(EnvFilePath())
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			if redact {
+				for key := range pairs {
+					if internal.IsSecretKey(key) {
+						pairs[key] = redactedValue
+					}
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			switch format {
+			case "json":
+				data, err := json.MarshalIndent(pairs, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to encode configuration: %w", err)
+				}
+				fmt.Fprintln(out, string(data))
+			case "shell":
+				keys := make([]string, 0, len(pairs))
+				for key := range pairs {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+				for _, key := range keys {
+					fmt.Fprintf(out, "export %s=%s\n", key, shellQuote(pairs[key]))
+				}
+			default:
+				return fmt.Errorf("unsupported export format %q (expected json or shell)", format)
+			}
+			return nil
+		},
+	}
+	exportCmd.Flags().StringVar(&format, "format", "json", "Output format: json or shell")
+	exportCmd.Flags().BoolVar(&redact, "redact", false, "Mask passwords, secrets and tokens")
+
+	configCmd.AddCommand(exportCmd)
+}
+
+// shellQuote wraps a value in single quotes, escaping embedded single quotes for POSIX shells.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
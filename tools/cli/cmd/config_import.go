@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+)
+
+// importedPair is a key read from an import file, kept in file order.
+type importedPair struct {
+	Key   string
+	Value string
+}
+
+func init() {
+	var overwrite bool
+
+	importCmd := &cobra.Command{
+		Use:   "import <FILE>",
+		Short: "Merge settings from a JSON or dotenv file into the env file",
+		Long: "Reads a JSON object or a dotenv file and merges its keys into the current .env file.\n" +
+			"Each value goes through the same validation as `config set`; rejected keys are reported and skipped.\n" +
+			"Use --overwrite=false to keep keys that are already set.",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pairs, err := readImportFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			envFile, err := internal.LoadEnvFile(EnvFilePath())
+			if err != nil {
+				return err
+			}
+			existing := envFile.Pairs()
+
+			imported, skipped := 0, 0
+			var rejected []string
+			for _, pair := range pairs {
+				if _, ok := existing[pair.Key]; ok && !overwrite {
+					skipped++
+					continue
+				}
+				sanitized, err := internal.ValidateEnvValue(envFile.Path, pair.Key, pair.Value)
+				if err != nil {
+					rejected = append(rejected, fmt.Sprintf("%s: %v", pair.Key, err))
+					continue
+				}
+				envFile.Set(pair.Key, sanitized)
+				imported++
+			}
+
+			for _, msg := range rejected {
+				color.HiYellow("[WARN] Rejected %s", msg)
+			}
+
+			if imported == 0 {
+				color.HiYellow("No keys imported (%d skipped, %d rejected)", skipped, len(rejected))
+				return nil
+			}
+
+			if err := internal.ValidateSizeLimits(envFile.Pairs()); err != nil {
+				return err
+			}
+			if err := envFile.WriteWithBackup(); err != nil {
+				return err
+			}
+
+			if err := internal.RunBuildScript(EnvFilePath()); err != nil {
+				fmt.Println("[WARN] Failed to rebuild configuration:", err)
+			}
+
+			color.HiGreen("Imported %d keys (%d skipped, %d rejected)", imported, skipped, len(rejected))
+			return nil
+		},
+	}
+	importCmd.Flags().BoolVar(&overwrite, "overwrite", true, "Replace keys that are already set in the env file")
+
+	configCmd.AddCommand(importCmd)
+}
+
+// readImportFile parses a JSON object or a dotenv file. Files ending in .json or
+// starting with '{' are treated as JSON; anything else is parsed as dotenv.
+func readImportFile(path string) ([]importedPair, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") || bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		// Keep numbers as written: float64 would turn 1000000 into 1e+06 and round large integers
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var raw map[string]interface{}
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("failed to parse %s as JSON: %w", path, err)
+		}
+		if decoder.More() {
+			return nil, fmt.Errorf("failed to parse %s as JSON: unexpected data after the top-level object", path)
+		}
+		keys := make([]string, 0, len(raw))
+		for key := range raw {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		pairs := make([]importedPair, 0, len(keys))
+		for _, key := range keys {
+			switch v := raw[key].(type) {
+			case string:
+				pairs = append(pairs, importedPair{Key: key, Value: v})
+			case json.Number:
+				pairs = append(pairs, importedPair{Key: key, Value: v.String()})
+			case bool:
+				pairs = append(pairs, importedPair{Key: key, Value: fmt.Sprint(v)})
+			case nil:
+				pairs = append(pairs, importedPair{Key: key})
+			default:
+				return nil, fmt.Errorf("failed to import %s: value must be a string, number or boolean", key)
+			}
+		}
+		return pairs, nil
+	}
+
+	envFile, err := internal.LoadEnvFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pairs []importedPair
+	for _, entry := range envFile.Entries {
+		if entry.IsPair {
+			pairs = append(pairs, importedPair{Key: entry.Key, Value: entry.Value})
+		}
+	}
+	return pairs, nil
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadImportFileKeepsJSONNumbers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := `{"VAULT_MAX_FILE_SIZE_MB": 1000000, "BIG": 9007199254740993, "RATIO": 0.5, "ENABLE_HTTPS": true, "VAULT_URL": null}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	pairs, err := readImportFile(path)
+	if err != nil {
+		t.Fatalf("readImportFile: %v", err)
+	}
+	want := []importedPair{
+		{Key: "BIG", Value: "9007199254740993"},
+		{Key: "ENABLE_HTTPS", Value: "true"},
+		{Key: "RATIO", Value: "0.5"},
+		{Key: "VAULT_MAX_FILE_SIZE_MB", Value: "1000000"},
+		{Key: "VAULT_URL"},
+	}
+	if !reflect.DeepEqual(pairs, want) {
+		t.Errorf("readImportFile() = %v, want %v", pairs, want)
+	}
+}
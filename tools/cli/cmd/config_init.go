@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+)
+
+func init() {
+	var force bool
+	var generateSecrets bool
+
+	initCmd := &cobra.Command{
+		Use:     "init",
+		Aliases: []string{"template"},
+		Short:   "Create the env file from env.template",
+		Long: "Copies env.template to the env file with the optional, commented-out variables enabled,\n" +
+			"then lists the secret variables that still need a value.\n" +
+			"--generate-secrets fills the required ones (SECRET_KEY, passwords) with random values.\n" +
+			"An existing env file is only replaced with --force; a backup is kept.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envPath, err := internal.ResolveEnvFilePath(EnvFilePath())
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(envPath); err == nil && !force {
+				return fmt.Errorf("%s already exists; use --force to replace it", envPath)
+			} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("failed to check %s: %w", envPath, err)
+			}
+
+			envFile, optional, err := internal.ScaffoldEnvFile(envPath)
+			if err != nil {
+				return err
+			}
+
+			var generated, missing []string
+			for _, entry := range envFile.Entries {
+				if !entry.IsPair || entry.Value != "" || !internal.IsSecretKey(entry.Key) {
+					continue
+				}
+				// Optional secrets are credentials of external services (SMTP, S3), never random
+				if generateSecrets && !optional[entry.Key] {
+					secret, err := internal.GenerateSecret()
+					if err != nil {
+						return err
+					}
+					envFile.Set(entry.Key, secret)
+					generated = append(generated, entry.Key)
+					continue
+				}
+				missing = append(missing, entry.Key)
+			}
+
+			if err := envFile.WriteWithBackup(); err != nil {
+				return err
+			}
+			color.HiGreen("Created %s from env.template (%d optional variables enabled)", envPath, len(optional))
+
+			for _, key := range generated {
+				fmt.Printf("  %s: generated\n", key)
+			}
+			if len(missing) > 0 {
+				color.HiYellow("Secret variables that still need a value:")
+				for _, key := range missing {
+					if optional[key] {
+						fmt.Printf("  %s (optional)\n", key)
+					} else {
+						fmt.Printf("  %s\n", key)
+					}
+				}
+				if !generateSecrets {
+					fmt.Println("Set them with 'leyzenctl config set', or rerun with --generate-secrets --force.")
+				}
+			}
+			return nil
+		},
+	}
+	initCmd.Flags().BoolVar(&force, "force", false, "Replace an existing env file")
+	initCmd.Flags().BoolVar(&generateSecrets, "generate-secrets", false, "Fill the required secret variables with random values")
+
+	configCmd.AddCommand(initCmd)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+)
+
+func init() {
+	var clear bool
+
+	useEnvCmd := &cobra.Command{
+		Use:   "use-env [PATH]",
+		Short: "Remember the env file to use for this project",
+		Long: "Records the env file in " + internal.ProjectSettingsFileName + " at the repository root so later commands use it without --env-file.\n" +
+			"The --env-file flag and LEYZEN_ENV_FILE still take precedence. Run without arguments to show the remembered file.",
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings, err := internal.LoadProjectSettings()
+			if err != nil {
+				return err
+			}
+
+			if clear {
+				settings.EnvFile = ""
+				if err := internal.SaveProjectSettings(settings); err != nil {
+					return err
+				}
+				color.HiGreen("Forgot remembered env file; commands default to .env")
+				return nil
+			}
+
+			if len(args) == 0 {
+				if settings.EnvFile == "" {
+					color.HiYellow("No env file remembered; commands default to .env")
+					return nil
+				}
+				fmt.Println(settings.EnvFile)
+				return nil
+			}
+
+			resolved, err := internal.ResolveEnvFilePath(args[0])
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(resolved); err != nil {
+				return fmt.Errorf("failed to use env file %s: %w", resolved, err)
+			}
+			settings.EnvFile = args[0]
+			if err := internal.SaveProjectSettings(settings); err != nil {
+				return err
+			}
+			color.HiGreen("Commands will now use %s", resolved)
+			return nil
+		},
+	}
+	useEnvCmd.Flags().BoolVar(&clear, "clear", false, "Forget the remembered env file")
+
+	configCmd.AddCommand(useEnvCmd)
+}
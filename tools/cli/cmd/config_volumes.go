@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+	"leyzenctl/internal/compose"
+)
+
+// volumePrefix is shared by every named volume the generated stack declares.
+const volumePrefix = "leyzen-"
+
+func init() {
+	var pruneOrphans bool
+	var yes bool
+
+	volumesCmd := &cobra.Command{
+		Use:   "volumes",
+		Short: "List the stack's docker volumes and flag orphaned ones",
+		Long: "Lists the docker volumes whose name starts with " + volumePrefix + " (<project>-" + volumePrefix + " under a compose\n" +
+			"project name) and marks those that docker-generated.yml no longer declares, typically left\n" +
+			"behind by renames or upgrades. Volumes of a disabled optional service, such as the Redis\n" +
+			"cache, are kept and never flagged.\n" +
+			"--prune-orphans removes them after confirmation (skipped with --yes). Their data is lost.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := internal.EnsureDockerGeneratedFileWithWriter(cmd.OutOrStdout(), cmd.ErrOrStderr(), EnvFilePath()); err != nil {
+				return fmt.Errorf("failed to initialize configuration: %w", err)
+			}
+			manifest, err := internal.LoadGeneratedManifest()
+			if err != nil {
+				return err
+			}
+			declared := make(map[string]bool, len(manifest.Volumes))
+			for key, volume := range manifest.Volumes {
+				if volume.Name != "" {
+					declared[volume.Name] = true
+				} else {
+					declared[key] = true
+				}
+			}
+
+			prefix := internal.ScopedName(volumePrefix)
+			// Volumes of a disabled optional service keep their data for when it is enabled again
+			optional := make(map[string]bool, len(compose.OptionalVolumeNames))
+			for _, name := range compose.OptionalVolumeNames {
+				optional[internal.ScopedName(name)] = true
+			}
+
+			volumes, err := internal.ListVolumes(prefix)
+			if err != nil {
+				return fmt.Errorf("failed to list volumes: %w", err)
+			}
+			if len(volumes) == 0 {
+				color.HiCyan("No %s* docker volumes found", prefix)
+				return nil
+			}
+
+			var orphans []string
+			for _, name := range volumes {
+				if declared[name] {
+					fmt.Printf("  %s %s\n", color.HiGreenString("✓"), name)
+					continue
+				}
+				if optional[name] {
+					fmt.Printf("  %s %s %s\n", color.HiCyanString("-"), name, color.HiCyanString("(service disabled)"))
+					continue
+				}
+				orphans = append(orphans, name)
+				fmt.Printf("  %s %s %s\n", color.HiYellowString("!"), name, color.HiYellowString("(orphaned)"))
+			}
+
+			if len(orphans) == 0 {
+				color.HiGreen("No orphaned volumes")
+				return nil
+			}
+			if !pruneOrphans {
+				color.HiYellow("%d orphaned volume(s); remove them with --prune-orphans", len(orphans))
+				return nil
+			}
+
+			if !yes && !internal.DryRun() {
+				fmt.Printf("Remove %d orphaned volume(s) and all their data? [y/N] ", len(orphans))
+				answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+				answer = strings.ToLower(strings.TrimSpace(answer))
+				if answer != "y" && answer != "yes" {
+					color.HiYellow("Prune cancelled")
+					return nil
+				}
+			}
+			if err := internal.RemoveVolumes(os.Stdout, os.Stderr, orphans); err != nil {
+				return fmt.Errorf("failed to remove volumes: %w", err)
+			}
+			if !internal.DryRun() {
+				color.HiGreen("Removed %d orphaned volume(s)", len(orphans))
+			}
+			return nil
+		},
+	}
+	volumesCmd.Flags().BoolVar(&pruneOrphans, "prune-orphans", false, "Remove the orphaned volumes")
+	volumesCmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation before pruning")
+
+	configCmd.AddCommand(volumesCmd)
+}
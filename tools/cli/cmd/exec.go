@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+)
+
+func init() {
+	var pick bool
+
+	execCmd := &cobra.Command{
+		Use:   "exec SERVICE [-- COMMAND...]",
+		Short: "Run a command inside a service container",
+		Long: "Attaches to a running container of SERVICE and runs COMMAND, or sh when none is given.\n" +
+			"A service name without its replica number (for example vault_web) targets the active replica;\n" +
+			"use --pick to choose the replica interactively.",
+		Example:      "  leyzenctl exec postgres -- psql -U leyzen\n  leyzenctl exec vault_web --pick",
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service := args[0]
+			command := args[1:]
+			if len(command) == 0 {
+				command = []string{"sh"}
+			}
+
+			if err := internal.EnsureDockerGeneratedFileWithWriter(cmd.OutOrStdout(), cmd.ErrOrStderr(), EnvFilePath()); err != nil {
+				return fmt.Errorf("failed to initialize configuration: %w", err)
+			}
+
+			containers, err := internal.ServiceContainers(EnvFilePath(), service)
+			if err != nil {
+				return fmt.Errorf("failed to resolve containers: %w", err)
+			}
+			if len(containers) == 0 {
+				return fmt.Errorf("no running container found for service %q", service)
+			}
+
+			container := containers[0]
+			if len(containers) > 1 && pick {
+				container, err = pickContainer(containers)
+				if err != nil {
+					return err
+				}
+			}
+
+			if internal.DryRun() {
+				color.HiYellow("[dry-run] Would run %s in %s", strings.Join(command, " "), container)
+				return nil
+			}
+
+			color.HiCyan("Running %s in %s...", strings.Join(command, " "), container)
+			if err := internal.ExecInContainer(container, command); err != nil {
+				// Pass the command's own exit status through instead of wrapping it
+				var exitErr *exec.ExitError
+				if errors.As(err, &exitErr) {
+					os.Exit(exitErr.ExitCode())
+				}
+				return fmt.Errorf("failed to exec in %s: %w", container, err)
+			}
+			return nil
+		},
+	}
+	execCmd.Flags().BoolVar(&pick, "pick", false, "Choose the replica when the service has several running containers")
+
+	rootCmd.AddCommand(execCmd)
+}
+
+// pickContainer asks the user to choose one of the containers; Enter keeps the first.
+func pickContainer(containers []string) (string, error) {
+	for i, name := range containers {
+		suffix := ""
+		if i == 0 {
+			suffix = " (active)"
+		}
+		fmt.Printf("  %d) %s%s\n", i+1, name, suffix)
+	}
+	fmt.Printf("Select a container [1-%d] (default 1): ", len(containers))
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return containers[0], nil
+	}
+	idx, err := strconv.Atoi(answer)
+	if err != nil || idx < 1 || idx > len(containers) {
+		return "", fmt.Errorf("invalid selection %q", answer)
+	}
+	return containers[idx-1], nil
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+)
+
+var haproxyCmd = &cobra.Command{
+	Use:          "haproxy",
+	Short:        "Inspect the generated HAProxy configuration",
+	SilenceUsage: true,
+}
+
+func init() {
+	var show bool
+
+	validateHAProxyCmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Generate haproxy.cfg and check its syntax",
+		Long: "Regenerates haproxy.cfg (and the SSL bundle when HTTPS is enabled), then runs 'haproxy -c' against it\n" +
+			"in a throwaway container so configuration errors surface before the proxy is redeployed.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := internal.RunBuildScript(EnvFilePath()); err != nil {
+				return fmt.Errorf("failed to generate configuration: %w", err)
+			}
+
+			if show {
+				repoRoot, err := internal.FindRepoRoot()
+				if err != nil {
+					return fmt.Errorf("failed to find repository root: %w", err)
+				}
+				data, err := os.ReadFile(filepath.Join(repoRoot, "infra", "haproxy", "haproxy.cfg"))
+				if err != nil {
+					return fmt.Errorf("failed to read haproxy.cfg: %w", err)
+				}
+				fmt.Println(string(data))
+			}
+
+			color.HiCyan("Checking HAProxy configuration...")
+			if err := internal.ValidateHAProxyConfig(os.Stdout, os.Stderr); err != nil {
+				return fmt.Errorf("failed to validate haproxy configuration: %w", err)
+			}
+			color.HiGreen("✓ HAProxy configuration is valid")
+			return nil
+		},
+	}
+	validateHAProxyCmd.Flags().BoolVar(&show, "show", false, "Print the generated haproxy.cfg before checking it")
+
+	haproxyCmd.AddCommand(validateHAProxyCmd)
+	rootCmd.AddCommand(haproxyCmd)
+}
@@ -19,6 +19,12 @@ func init() {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			tail, _ := cmd.Flags().GetString("tail")
 			follow, _ := cmd.Flags().GetBool("follow")
+			if err := internal.EnsureDockerGeneratedFileWithWriter(cmd.OutOrStdout(), cmd.ErrOrStderr(), EnvFilePath()); err != nil {
+				return fmt.Errorf("failed to initialize configuration: %w", err)
+			}
+			if err := internal.ValidateServiceNames(EnvFilePath(), args); err != nil {
+				return err
+			}
 
 			composeArgs := []string{"logs"}
 			if follow {
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+
+	"leyzenctl/internal"
+)
+
+// logJSON switches the lifecycle commands to JSON events on stdout (--log-json).
+var logJSON bool
+
+// lifecycleOutput reports the progress of start, stop, build and restart: colored
+// lines by default, or one {ts, action, level, message} event per line with --log-json.
+type lifecycleOutput struct {
+	action string
+	writer *internal.JSONLogWriter
+}
+
+func newLifecycleOutput(action string) *lifecycleOutput {
+	out := &lifecycleOutput{action: action}
+	if logJSON {
+		out.writer = internal.NewJSONLogWriter(os.Stdout, action)
+	}
+	if internal.DryRun() {
+		out.Notice("Dry run: docker compose commands and configuration writes are printed, not executed")
+	}
+	return out
+}
+
+func (o *lifecycleOutput) print(level string, colorize func(string, ...interface{}) string, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if o.writer != nil {
+		internal.WriteLogEvent(os.Stdout, o.action, level, message)
+		return
+	}
+	fmt.Fprintln(color.Output, colorize("%s", message))
+}
+
+func (o *lifecycleOutput) Info(format string, args ...interface{}) {
+	o.print("info", color.HiCyanString, format, args...)
+}
+
+func (o *lifecycleOutput) Success(format string, args ...interface{}) {
+	o.print("info", color.HiGreenString, format, args...)
+}
+
+// Notice highlights a step in yellow without marking it as a warning.
+func (o *lifecycleOutput) Notice(format string, args ...interface{}) {
+	o.print("info", color.HiYellowString, format, args...)
+}
+
+func (o *lifecycleOutput) Warn(format string, args ...interface{}) {
+	o.print("warn", color.HiYellowString, format, args...)
+}
+
+// streams returns where command output goes: the JSON writer, or the terminal.
+func (o *lifecycleOutput) streams() (io.Writer, io.Writer) {
+	if o.writer != nil {
+		return o.writer, o.writer
+	}
+	return os.Stdout, os.Stderr
+}
+
+// Compose runs docker compose with its output routed through the reporter.
+func (o *lifecycleOutput) Compose(args ...string) error {
+	stdout, stderr := o.streams()
+	err := internal.RunComposeWithWriter(stdout, stderr, EnvFilePath(), args...)
+	if o.writer != nil {
+		o.writer.Flush()
+	}
+	return err
+}
+
+// PrepareConfig regenerates the configuration so the latest .env changes are applied.
+// With noRebuild, an existing docker-generated.yml is used as is.
+func (o *lifecycleOutput) PrepareConfig(noRebuild bool) error {
+	stdout, stderr := o.streams()
+	defer func() {
+		if o.writer != nil {
+			o.writer.Flush()
+		}
+	}()
+	if noRebuild {
+		if err := internal.EnsureDockerGeneratedFileWithWriter(stdout, stderr, EnvFilePath()); err != nil {
+			return fmt.Errorf("failed to initialize configuration: %w", err)
+		}
+		return nil
+	}
+	if err := internal.GenerateConfig(stdout, stderr, EnvFilePath()); err != nil {
+		return fmt.Errorf("failed to generate configuration: %w", err)
+	}
+	return nil
+}
+
+// PromoteFiles moves files still in tmpfs to persistent storage before the stack goes
+// down. A failure is only a warning: the lifecycle command carries on.
+func (o *lifecycleOutput) PromoteFiles(before string) {
+	if internal.DryRun() {
+		o.Notice("[dry-run] Would promote tmpfs files to persistent storage before %s", before)
+		return
+	}
+	o.Notice("Promoting files to persistent storage...")
+	if err := internal.PrepareRotation(EnvFilePath()); err != nil {
+		o.Warn("[WARN] Warning: Failed to promote files before %s: %v", before, err)
+		o.Warn("  Files in tmpfs will be lost. Continuing with %s...", before)
+		return
+	}
+	o.Success("Files promoted to persistent storage")
+}
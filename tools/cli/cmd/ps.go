@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+)
+
+func init() {
+	var jsonOut bool
+	var filter string
+
+	psCmd := &cobra.Command{
+		Use:   "ps",
+		Short: "List service containers and their state",
+		Long: "Prints the state of every service in docker-generated.yml without the health probes of 'status'.\n" +
+			"Use --json for a machine-readable array and --filter to keep running or exited services only.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if filter != "all" && filter != "running" && filter != "exited" {
+				return fmt.Errorf("invalid --filter %q (expected running, exited or all)", filter)
+			}
+
+			// Keep generation messages out of the JSON output
+			progress := cmd.OutOrStdout()
+			if jsonOut {
+				progress = cmd.ErrOrStderr()
+			}
+			if err := internal.EnsureDockerGeneratedFileWithWriter(progress, cmd.ErrOrStderr(), EnvFilePath()); err != nil {
+				return fmt.Errorf("failed to initialize configuration: %w", err)
+			}
+
+			statuses, err := internal.GetProjectStatuses(EnvFilePath())
+			if err != nil {
+				return fmt.Errorf("failed to list services: %w", err)
+			}
+
+			filtered := []internal.ProjectStatus{}
+			for _, st := range statuses {
+				if matchesPSFilter(st.Status, filter) {
+					filtered = append(filtered, st)
+				}
+			}
+
+			if jsonOut {
+				b, err := json.MarshalIndent(filtered, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(b))
+				return nil
+			}
+
+			nameWidth := len("NAME")
+			statusWidth := len("STATUS")
+			for _, st := range filtered {
+				nameWidth = max(nameWidth, len(st.Name))
+				// FormatStatusColor prefixes a two-column symbol
+				statusWidth = max(statusWidth, utf8.RuneCountInString(st.Status)+2)
+			}
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "%-*s  %-*s  %s\n", nameWidth, "NAME", statusWidth, "STATUS", "AGE")
+			for _, st := range filtered {
+				padding := strings.Repeat(" ", statusWidth-utf8.RuneCountInString(st.Status)-2)
+				fmt.Fprintf(out, "%-*s  %s%s  %s\n", nameWidth, st.Name, internal.FormatStatusColor(st.Status), padding, st.Age)
+			}
+			return nil
+		},
+	}
+	psCmd.Flags().BoolVar(&jsonOut, "json", false, "Print the services as a JSON array")
+	psCmd.Flags().StringVar(&filter, "filter", "all", "Show only running, exited or all services")
+
+	rootCmd.AddCommand(psCmd)
+}
+
+// matchesPSFilter reports whether a `docker compose ps` status string passes the filter.
+func matchesPSFilter(status, filter string) bool {
+	lower := strings.ToLower(status)
+	switch filter {
+	case "running":
+		return strings.HasPrefix(lower, "up")
+	case "exited":
+		return strings.HasPrefix(lower, "exited")
+	}
+	return true
+}
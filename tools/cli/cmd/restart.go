@@ -4,63 +4,63 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"leyzenctl/internal"
 )
 
 func init() {
+	var noRebuild bool
+
 	restartCmd := &cobra.Command{
-		Use:          "restart [services...]",
-		Short:        "Restart the Leyzen Vault Docker stack or specific services",
+		Use:   "restart [services...]",
+		Short: "Restart the Leyzen Vault Docker stack or specific services",
+		Long: "Regenerates the configuration and restarts the stack, or only the given services.\n" +
+			"--no-rebuild (advanced) reuses the existing docker-generated.yml, e.g. after editing it by hand for testing.",
 		Args:         cobra.ArbitraryArgs,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newLifecycleOutput("restart")
 			if len(args) > 0 {
-				color.HiCyan("Restarting services: %s...", strings.Join(args, ", "))
-				// Always regenerate configuration to ensure latest changes are applied
-				if err := internal.RunBuildScript(EnvFilePath()); err != nil {
-					return fmt.Errorf("failed to generate configuration: %w", err)
+				out.Info("Restarting services: %s...", strings.Join(args, ", "))
+				if err := out.PrepareConfig(noRebuild); err != nil {
+					return err
+				}
+				if err := internal.ValidateServiceNames(EnvFilePath(), args); err != nil {
+					return err
 				}
 				// For individual services, we use stop + up to ensure clean state
-				if err := internal.RunCompose(EnvFilePath(), append([]string{"stop"}, args...)...); err != nil {
+				if err := out.Compose(append([]string{"stop"}, args...)...); err != nil {
 					return fmt.Errorf("failed to stop services: %w", err)
 				}
-				if err := internal.RunCompose(EnvFilePath(), append([]string{"up", "-d", "--remove-orphans"}, args...)...); err != nil {
+				if err := out.Compose(append([]string{"up", "-d", "--remove-orphans"}, args...)...); err != nil {
 					return fmt.Errorf("failed to start services: %w", err)
 				}
-				color.HiGreen("Successfully restarted services")
+				out.Success("Successfully restarted services")
 				return nil
 			}
 
-			color.HiCyan("Restarting Docker stack...")
+			out.Info("Restarting Docker stack...")
 
-			// Promote files to persistent storage before shutdown
-			color.HiYellow("Promoting files to persistent storage...")
-			if err := internal.PrepareRotation(EnvFilePath()); err != nil {
-				// Log warning but don't fail - files may still be in tmpfs
-				color.HiYellow("[WARN] Warning: Failed to promote files before restart: %v", err)
-				color.HiYellow("  Files in tmpfs will be lost. Continuing with restart...")
-			} else {
-				color.HiGreen("Files promoted to persistent storage")
-			}
+			out.PromoteFiles("restart")
 
-			color.HiYellow("Stopping containers...")
-			if err := internal.RunCompose(EnvFilePath(), "down", "--remove-orphans"); err != nil {
+			out.Notice("Stopping containers...")
+			if err := out.Compose("down", "--remove-orphans"); err != nil {
 				return fmt.Errorf("failed to stop stack: %w", err)
 			}
-			if err := internal.RunBuildScript(EnvFilePath()); err != nil {
-				return fmt.Errorf("failed to generate configuration: %w", err)
+			if err := out.PrepareConfig(noRebuild); err != nil {
+				return err
 			}
-			color.HiYellow("Starting containers...")
-			if err := internal.RunCompose(EnvFilePath(), "up", "-d", "--remove-orphans"); err != nil {
+			out.Notice("Starting containers...")
+			if err := out.Compose("up", "-d", "--remove-orphans"); err != nil {
 				return fmt.Errorf("failed to start stack: %w", err)
 			}
-			color.HiGreen("Successfully restarted Docker stack")
+			out.Success("Successfully restarted Docker stack")
 			return nil
 		},
 	}
 
+	restartCmd.Flags().BoolVar(&noRebuild, noRebuildFlag, false, noRebuildUsage)
+
 	rootCmd.AddCommand(restartCmd)
 }
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+	"leyzenctl/internal/compose"
+)
+
+func init() {
+	var yes bool
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore [BACKUP_ID]",
+		Short: "List database backups or restore one",
+		Long: "Without arguments, lists the backups available to the vault application.\n" +
+			"With a backup id, replaces the current database with that backup. HAProxy is stopped during the restore\n" +
+			"and the vault services that were running are restarted afterwards.\n" +
+			"You are asked to confirm unless --yes is given.",
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := internal.EnsureDockerGeneratedFileWithWriter(cmd.OutOrStdout(), cmd.ErrOrStderr(), EnvFilePath()); err != nil {
+				return fmt.Errorf("failed to initialize configuration: %w", err)
+			}
+			if len(args) == 0 {
+				return listBackups()
+			}
+			backupID := args[0]
+
+			if internal.DryRun() {
+				color.HiYellow("[dry-run] Would stop %s, restore backup %s in a healthy vault container,", compose.HAProxyContainerName, backupID)
+				color.HiYellow("[dry-run] restart the running vault services and start %s again", compose.HAProxyContainerName)
+				return nil
+			}
+			if !yes {
+				fmt.Printf("Restoring %s replaces the current database. Continue? [y/N] ", backupID)
+				answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+				answer = strings.ToLower(strings.TrimSpace(answer))
+				if answer != "y" && answer != "yes" {
+					color.HiYellow("Restore cancelled")
+					return nil
+				}
+			}
+
+			// Only the replicas running now come back; the orchestrator's standbys stay stopped
+			services, err := runningVaultServices()
+			if err != nil {
+				return fmt.Errorf("failed to list running vault services: %w", err)
+			}
+
+			color.HiYellow("Stopping HAProxy...")
+			if err := internal.RunCompose(EnvFilePath(), "stop", compose.HAProxyContainerName); err != nil {
+				return fmt.Errorf("failed to stop haproxy: %w", err)
+			}
+
+			color.HiCyan("Restoring backup %s...", backupID)
+			restoreErr := internal.RestoreBackup(EnvFilePath(), backupID, os.Stdout, os.Stderr)
+
+			// Bring the stack back even when the restore failed
+			if restoreErr == nil {
+				if len(services) > 0 {
+					color.HiYellow("Restarting vault services...")
+					if err := internal.RunCompose(EnvFilePath(), append([]string{"restart"}, services...)...); err != nil {
+						color.HiYellow("[WARN] Failed to restart vault services: %v", err)
+					}
+				}
+			}
+			color.HiYellow("Starting HAProxy...")
+			if err := internal.RunCompose(EnvFilePath(), "start", compose.HAProxyContainerName); err != nil {
+				color.HiYellow("[WARN] Failed to start haproxy: %v", err)
+			}
+
+			if restoreErr != nil {
+				return fmt.Errorf("failed to restore backup: %w", restoreErr)
+			}
+			color.HiGreen("Backup %s restored successfully", backupID)
+			return nil
+		},
+	}
+	restoreCmd.Flags().BoolVarP(&yes, "yes", "y", false, "Restore without asking for confirmation")
+
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func listBackups() error {
+	backups, err := internal.ListBackups(EnvFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+	if len(backups) == 0 {
+		color.HiYellow("No backups found")
+		return nil
+	}
+
+	fmt.Printf("%-36s  %-25s  %10s  %s\n", "ID", "CREATED", "SIZE", "LOCATION")
+	for _, b := range backups {
+		fmt.Printf("%-36s  %-25s  %10s  %s\n", b.ID, b.CreatedAt, internal.FormatBytes(b.SizeBytes), b.StorageLocation)
+	}
+	return nil
+}
+
+// runningVaultServices lists the vault application services with a running container.
+// The standby replicas the orchestrator keeps stopped are left out, so restarting the
+// result does not start them.
+func runningVaultServices() ([]string, error) {
+	output, err := internal.DockerComposePS(EnvFilePath(), "--filter", "status=running", "--format", "{{.Service}}")
+	if err != nil {
+		return nil, err
+	}
+	app, webPrefix := internal.VaultServiceNames(EnvFilePath())
+	var names []string
+	for _, name := range strings.Fields(output) {
+		if name == app || strings.HasPrefix(name, webPrefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -2,20 +2,31 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
+	"leyzenctl/internal"
 	"leyzenctl/internal/ui"
 	"leyzenctl/internal/version"
 )
 
 var (
 	envFile     string
+	envFileFlag *pflag.Flag
+	profiles    []string
+	projectName string
+	dockerCtx   string
+	quiet       bool
+	dryRun      bool
+	themeName   string
+	timeout     time.Duration
 	versionFlag string
 	rootCmd     = &cobra.Command{
 		Use:   "leyzenctl",
@@ -23,7 +34,7 @@ var (
 		Long: color.HiCyanString("Leyzenctl orchestrates the Leyzen Vault Docker stack and configuration.\n\n") +
 			"Run 'leyzenctl' without arguments to launch the interactive dashboard, or use subcommands like 'start', 'stop', 'status'.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return ui.StartApp(cmd.Context(), EnvFilePath())
+			return ui.StartApp(cmd.Context(), EnvFilePath(), projectName, themeName)
 		},
 	}
 )
@@ -36,6 +47,15 @@ func init() {
 	rootCmd.SilenceUsage = true
 	rootCmd.SilenceErrors = true
 	rootCmd.PersistentFlags().StringVar(&envFile, "env-file", defaultEnv, "Path to the environment file to use")
+	envFileFlag = rootCmd.PersistentFlags().Lookup("env-file")
+	rootCmd.PersistentFlags().StringSliceVar(&profiles, "profile", nil, "Compose profile to enable (repeatable); defaults to all profiles in the generated manifest")
+	rootCmd.PersistentFlags().StringVar(&projectName, "project-name", "", "Compose project name (defaults to COMPOSE_PROJECT_NAME from the environment or env file)")
+	rootCmd.PersistentFlags().StringVar(&dockerCtx, "docker-context", "", "Docker context to target (sets DOCKER_CONTEXT); DOCKER_HOST is honored as well")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Do not print the docker-generated.yml diff when the configuration is regenerated")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print the docker compose commands and configuration writes instead of running them")
+	rootCmd.PersistentFlags().BoolVar(&logJSON, "log-json", false, "Emit start/stop/build/restart progress as JSON events ({ts, action, level, message}) on stdout")
+	rootCmd.PersistentFlags().StringVar(&themeName, "theme", ui.ThemeDark, "Color scheme: dark, light or none (none, like NO_COLOR, disables colors)")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", internal.DefaultCommandTimeout, "Maximum duration of each docker command (e.g. 30s, 45m); when set, also replaces the 30m limit of backups and restores and the 5m limit of internal API calls")
 	rootCmd.PersistentFlags().StringVarP(&versionFlag, "version", "v", "", "Print version information and exit; use 'json' for JSON output")
 	if f := rootCmd.PersistentFlags().Lookup("version"); f != nil {
 		f.NoOptDefVal = "text"
@@ -48,18 +68,102 @@ func init() {
 			printVersion(versionFlag)
 			os.Exit(0)
 		}
-		return nil
+		if err := ui.ValidateThemeName(themeName); err != nil {
+			return err
+		}
+		// NO_COLOR applies unless a theme was requested explicitly
+		if os.Getenv("NO_COLOR") != "" && !cmd.Flags().Changed("theme") {
+			themeName = ui.ThemeNone
+		}
+		if themeName == ui.ThemeNone {
+			ui.DisableColors()
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("--timeout must be positive, got %s", timeout)
+		}
+		if cmd.Flags().Changed("timeout") {
+			internal.SetCommandTimeout(timeout)
+		}
+		if dockerCtx != "" {
+			if err := internal.SetDockerContext(dockerCtx); err != nil {
+				return fmt.Errorf("failed to set docker context: %w", err)
+			}
+		}
+		internal.SetGeneratedDiffOutput(!quiet)
+		internal.SetDryRun(dryRun)
+		internal.SetComposeProfiles(profiles)
+		internal.SetComposeProjectName(internal.ResolveComposeProjectName(EnvFilePath(), projectName))
+		if envOptionalCommands[cmd.CommandPath()] || strings.HasPrefix(cmd.CommandPath(), "leyzenctl completion") {
+			return nil
+		}
+		return checkEnvFile()
+	}
+}
+
+// envOptionalCommands run without an existing env file: the dashboard opens the wizard
+// for it, and the others create, select or check it.
+var envOptionalCommands = map[string]bool{
+	"leyzenctl":                 true,
+	"leyzenctl help":            true,
+	"leyzenctl version":         true,
+	"leyzenctl self-update":     true,
+	"leyzenctl config init":     true,
+	"leyzenctl config use-env":  true,
+	"leyzenctl config validate": true,
+}
+
+// checkEnvFile fails early when the env file is missing or unreadable, rather than
+// letting the command carry on with an empty configuration.
+func checkEnvFile() error {
+	path, err := internal.ResolveEnvFilePath(EnvFilePath())
+	if err != nil {
+		return err
 	}
+	info, err := os.Stat(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("env file %s not found; create it with 'leyzenctl config init' or pick another one with --env-file", path)
+	case err != nil:
+		return fmt.Errorf("cannot read env file %s: %w", path, err)
+	case info.IsDir():
+		return fmt.Errorf("env file %s is a directory", path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("cannot read env file %s: %w", path, err)
+	}
+	return f.Close()
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, color.HiRedString("Error: %v", err))
+	if cmd, err := rootCmd.ExecuteC(); err != nil {
+		var buildErr *internal.BuildConfigError
+		switch {
+		case logJSON:
+			internal.WriteLogEvent(os.Stdout, cmd.Name(), "error", err.Error())
+		case errors.As(err, &buildErr) && buildErr.MissingVariable() != "":
+			name := buildErr.MissingVariable()
+			fmt.Fprintln(os.Stderr, color.HiRedString("[CONFIG INCOMPLETE] configuration incomplete: set %s", name))
+			fmt.Fprintln(os.Stderr, color.HiYellowString("Add it to %s, e.g. with 'leyzenctl config set %s <value>' (or --generate for a random secret), then retry.", EnvFilePath(), name))
+		case errors.As(err, &buildErr):
+			// Tell a broken configuration apart from docker compose failing
+			fmt.Fprintln(os.Stderr, color.HiRedString("[CONFIG GENERATION FAILED] %v", buildErr.Err))
+			fmt.Fprintln(os.Stderr, color.HiYellowString("docker-generated.yml was not updated. Run 'leyzenctl config validate' to check %s.", EnvFilePath()))
+		default:
+			fmt.Fprintln(os.Stderr, color.HiRedString("Error: %v", err))
+		}
 		os.Exit(1)
 	}
 }
 
+// EnvFilePath returns the env file to use. Precedence: --env-file, LEYZEN_ENV_FILE,
+// the file remembered with 'config use-env', then .env.
 func EnvFilePath() string {
+	if (envFileFlag == nil || !envFileFlag.Changed) && os.Getenv("LEYZEN_ENV_FILE") == "" {
+		if settings, err := internal.LoadProjectSettings(); err == nil && settings.EnvFile != "" {
+			return settings.EnvFile
+		}
+	}
 	if envFile == "" {
 		return ".env"
 	}
@@ -107,30 +211,8 @@ func printVersion(format string) {
 	fmt.Printf("leyzenctl %s (commit %s, built %s)\n", v, c, d)
 }
 
+// latestStable returns the latest release tag, cached for a few hours (see --refresh on
+// the version command).
 func latestStable() string {
-	client := &http.Client{Timeout: 3 * time.Second}
-	req, err := http.NewRequest("GET", "https://api.github.com/repos/3xpyth0n/leyzen-vault/releases/latest", nil)
-	if err != nil {
-		return ""
-	}
-	req.Header.Set("Accept", "application/vnd.github+json")
-	resp, err := client.Do(req)
-	if err != nil {
-		return ""
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != 200 {
-		return ""
-	}
-	type latestResp struct {
-		TagName string `json:"tag_name"`
-	}
-	var lr latestResp
-	if err := json.NewDecoder(resp.Body).Decode(&lr); err != nil {
-		return ""
-	}
-	if lr.TagName == "" {
-		return ""
-	}
-	return lr.TagName
+	return internal.LatestRelease(refreshRelease)
 }
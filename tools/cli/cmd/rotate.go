@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+)
+
+const rotateHealthTimeout = 2 * time.Minute
+
+func init() {
+	rotateCmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Force an immediate container rotation",
+		Long: "Promotes tmpfs data to persistent storage, then asks the orchestrator to rotate to a fresh\n" +
+			"web container. When ORCHESTRATOR_ENABLED is off, the vault app container (VAULT_APP_NAME) is\n" +
+			"recreated instead. The command waits for the new container to pass its healthcheck before\n" +
+			"reporting success.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envFile := EnvFilePath()
+			if err := internal.EnsureDockerGeneratedFileWithWriter(cmd.OutOrStdout(), cmd.ErrOrStderr(), envFile); err != nil {
+				return fmt.Errorf("failed to initialize configuration: %w", err)
+			}
+
+			enabled, err := internal.OrchestratorEnabled(envFile)
+			if err != nil {
+				return fmt.Errorf("failed to read ORCHESTRATOR_ENABLED: %w", err)
+			}
+
+			if internal.DryRun() {
+				color.HiYellow("[dry-run] Would promote tmpfs data to persistent storage")
+				if enabled {
+					color.HiYellow("[dry-run] Would ask the orchestrator to rotate to a fresh web container")
+				} else {
+					app, _ := internal.VaultServiceNames(envFile)
+					color.HiYellow("[dry-run] Would recreate %s", app)
+				}
+				color.HiYellow("[dry-run] Would wait up to %s for the new container to be healthy", rotateHealthTimeout)
+				return nil
+			}
+
+			color.HiCyan("Promoting tmpfs data to persistent storage...")
+			if err := internal.PrepareRotation(envFile); err != nil {
+				color.HiYellow("[WARN] Failed to prepare rotation: %v", err)
+			}
+
+			var container string
+			if enabled {
+				color.HiCyan("Requesting rotation from the orchestrator...")
+				container, err = internal.ForceRotation(envFile)
+				if err != nil {
+					return fmt.Errorf("failed to rotate: %w", err)
+				}
+			} else {
+				color.HiYellow("[WARN] ORCHESTRATOR_ENABLED is off, so there is no replica to rotate to.")
+				app, _ := internal.VaultServiceNames(envFile)
+				color.HiYellow("Falling back to recreating %s; the vault is unavailable until it is healthy again.", app)
+				if err := internal.RunCompose(envFile, "up", "-d", "--force-recreate", app); err != nil {
+					return fmt.Errorf("failed to recreate %s: %w", app, err)
+				}
+				container = internal.ScopedName(app)
+			}
+
+			color.HiCyan("Waiting for %s to become healthy...", container)
+			if err := internal.WaitForHealthy(container, rotateHealthTimeout); err != nil {
+				return fmt.Errorf("rotation did not complete: %w", err)
+			}
+			color.HiGreen("Rotation complete: %s is active and healthy", container)
+			return nil
+		},
+	}
+
+	rootCmd.AddCommand(rotateCmd)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+	"leyzenctl/internal/version"
+)
+
+func init() {
+	var check bool
+	var yes bool
+
+	selfUpdateCmd := &cobra.Command{
+		Use:   "self-update",
+		Short: "Replace this leyzenctl binary with the latest release",
+		Long: "Downloads the leyzenctl binary of the latest GitHub release for this platform, verifies it\n" +
+			"against the release's checksums.txt and atomically replaces the running binary.\n" +
+			"--check only reports whether an update is available. You are asked to confirm the swap\n" +
+			"unless --yes is given. Nightly and development builds are never replaced.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			current := version.Version
+			if strings.Contains(current, "nightly") {
+				return fmt.Errorf("leyzenctl %s is a nightly build and cannot self-update; install a release from %s instead", current, strings.TrimSuffix(releasesURL, "tag/"))
+			}
+			if _, ok := parseVersion(current); !ok {
+				return fmt.Errorf("leyzenctl %s is a development build and cannot self-update", current)
+			}
+
+			latest := latestStable()
+			if latest == "" {
+				return fmt.Errorf("failed to determine the latest release from GitHub")
+			}
+			if !isNewerVersion(latest, current) {
+				color.HiGreen("leyzenctl %s is up to date (latest release: %s)", current, latest)
+				return nil
+			}
+			if check {
+				color.HiYellow("Update available: %s -> %s (%s%s)", current, latest, releasesURL, latest)
+				return nil
+			}
+
+			path, err := internal.CurrentExecutable()
+			if err != nil {
+				return err
+			}
+			assets, err := internal.ReleaseAssets(latest)
+			if err != nil {
+				return err
+			}
+			asset, err := internal.PlatformAsset(assets)
+			if err != nil {
+				return err
+			}
+
+			if internal.DryRun() {
+				color.HiYellow("[dry-run] Would replace %s with %s from %s", path, asset.Name, latest)
+				return nil
+			}
+			if !yes {
+				fmt.Printf("Replace %s (%s) with %s? [y/N] ", path, current, latest)
+				answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+				answer = strings.ToLower(strings.TrimSpace(answer))
+				if answer != "y" && answer != "yes" {
+					color.HiYellow("Update cancelled")
+					return nil
+				}
+			}
+
+			color.HiCyan("Downloading %s...", asset.Name)
+			data, err := internal.DownloadVerifiedAsset(assets, asset)
+			if err != nil {
+				return err
+			}
+			if err := internal.ReplaceExecutable(path, data); err != nil {
+				return err
+			}
+			color.HiGreen("Updated leyzenctl %s -> %s", current, latest)
+			return nil
+		},
+	}
+	selfUpdateCmd.Flags().BoolVar(&check, "check", false, "Only report whether a newer release is available")
+	selfUpdateCmd.Flags().BoolVarP(&yes, "yes", "y", false, "Replace the binary without asking for confirmation")
+	selfUpdateCmd.Flags().BoolVar(&refreshRelease, "refresh", false, "Ignore the cached latest release and query GitHub again")
+
+	rootCmd.AddCommand(selfUpdateCmd)
+}
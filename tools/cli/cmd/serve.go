@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+	"leyzenctl/internal/status"
+)
+
+func init() {
+	var (
+		addr     string
+		interval time.Duration
+	)
+
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve Leyzen Vault status over HTTP",
+		Long: "Runs a small HTTP agent that collects status periodically and serves the latest result.\n" +
+			"GET /status returns the JSON status; GET /healthz reports that the agent is running.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval < time.Second {
+				interval = time.Second
+			}
+			if err := internal.EnsureDockerGeneratedFileWithWriter(cmd.OutOrStdout(), cmd.ErrOrStderr(), EnvFilePath()); err != nil {
+				return fmt.Errorf("failed to initialize configuration: %w", err)
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			agent := status.NewAgent(EnvFilePath(), interval, 800*time.Millisecond)
+			go agent.Run(ctx)
+
+			server := &http.Server{
+				Addr:              addr,
+				Handler:           agent.Handler(),
+				ReadHeaderTimeout: 5 * time.Second,
+			}
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = server.Shutdown(shutdownCtx)
+			}()
+
+			color.HiCyan("Serving status on %s (refresh every %s)", addr, interval)
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("failed to serve status: %w", err)
+			}
+			return nil
+		},
+	}
+	serveCmd.Flags().StringVar(&addr, "addr", ":9999", "Address to listen on")
+	serveCmd.Flags().DurationVar(&interval, "interval", 15*time.Second, "Time between status collections (minimum 1s)")
+
+	rootCmd.AddCommand(serveCmd)
+}
@@ -3,44 +3,162 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"leyzenctl/internal"
 )
 
+const (
+	defaultWaitTimeout = 5 * time.Minute
+	waitPollInterval   = 2 * time.Second
+	waitLogLines       = 20
+)
+
 func init() {
+	var noRebuild bool
+	var wait bool
+	var waitTimeout time.Duration
+
 	startCmd := &cobra.Command{
-		Use:          "start [services...]",
-		Short:        "Start the Leyzen Vault Docker stack or specific services",
+		Use:   "start [services...]",
+		Short: "Start the Leyzen Vault Docker stack or specific services",
+		Long: "Regenerates the configuration and starts the stack, or only the given services.\n" +
+			"--no-rebuild (advanced) reuses the existing docker-generated.yml, e.g. after editing it by hand for testing.\n" +
+			"--wait blocks until every started service is up and healthy, and fails with the last log\n" +
+			"lines of the services that are not once --wait-timeout elapses.",
 		Args:         cobra.ArbitraryArgs,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Always regenerate configuration before starting to ensure latest changes are applied
-			if err := internal.RunBuildScript(EnvFilePath()); err != nil {
-				return fmt.Errorf("failed to generate configuration: %w", err)
+			out := newLifecycleOutput("start")
+			if err := out.PrepareConfig(noRebuild); err != nil {
+				return err
+			}
+			if err := internal.ValidateServiceNames(EnvFilePath(), args); err != nil {
+				return err
 			}
 
 			if len(args) > 0 {
-				color.HiCyan("Starting services: %s...", strings.Join(args, ", "))
+				out.Info("Starting services: %s...", strings.Join(args, ", "))
 			} else {
-				color.HiCyan("Starting Docker stack...")
+				out.Info("Starting Docker stack...")
 			}
 
 			composeArgs := append([]string{"up", "-d", "--remove-orphans"}, args...)
-			if err := internal.RunCompose(EnvFilePath(), composeArgs...); err != nil {
+			if err := out.Compose(composeArgs...); err != nil {
 				return fmt.Errorf("failed to start: %w", err)
 			}
 
+			if wait {
+				if waitTimeout <= 0 {
+					return fmt.Errorf("--wait-timeout must be positive")
+				}
+				if internal.DryRun() {
+					out.Notice("[dry-run] Would wait up to %s for the services to be ready", waitTimeout)
+				} else if err := waitForServices(out, args, waitTimeout); err != nil {
+					return err
+				}
+			}
+
 			if len(args) > 0 {
-				color.HiGreen("Successfully started services")
+				out.Success("Successfully started services")
 			} else {
-				color.HiGreen("Successfully started Docker stack")
+				out.Success("Successfully started Docker stack")
 			}
 			return nil
 		},
 	}
 
+	startCmd.Flags().BoolVar(&noRebuild, noRebuildFlag, false, noRebuildUsage)
+	startCmd.Flags().BoolVar(&wait, "wait", false, "Wait until the services are up and healthy")
+	startCmd.Flags().DurationVar(&waitTimeout, "wait-timeout", defaultWaitTimeout, "How long --wait waits before failing")
+
 	rootCmd.AddCommand(startCmd)
 }
+
+const (
+	noRebuildFlag  = "no-rebuild"
+	noRebuildUsage = "Advanced: keep the existing docker-generated.yml instead of regenerating it (a missing file is still created)"
+)
+
+// waitForServices polls the project statuses until the given services, or every service
+// with a container when none are given, are ready. Each change in readiness is printed.
+// On timeout the last log lines of the services still pending are shown.
+func waitForServices(out *lifecycleOutput, services []string, timeout time.Duration) error {
+	wanted := make(map[string]bool, len(services))
+	for _, service := range services {
+		wanted[service] = true
+	}
+
+	out.Info("Waiting up to %s for services to become ready...", timeout)
+	deadline := time.Now().Add(timeout)
+	lastSummary := ""
+	var pending []internal.ProjectStatus
+	for {
+		statuses, err := internal.GetProjectStatuses(EnvFilePath())
+		if err != nil {
+			return fmt.Errorf("failed to get service statuses: %w", err)
+		}
+
+		pending = pending[:0]
+		var lines []string
+		total := 0
+		for _, st := range statuses {
+			// Services outside the enabled profiles never get a container
+			if (len(wanted) > 0 && !wanted[st.Name]) || (len(wanted) == 0 && st.Status == "Not created") {
+				continue
+			}
+			total++
+			mark := "✓"
+			if !serviceReady(st.Status) {
+				mark = "…"
+				pending = append(pending, st)
+			}
+			lines = append(lines, fmt.Sprintf("  %s %s: %s", mark, st.Name, st.Status))
+		}
+
+		summary := strings.Join(lines, "\n")
+		if summary != lastSummary {
+			out.Notice("Ready: %d/%d", total-len(pending), total)
+			for _, line := range lines {
+				out.Notice("%s", line)
+			}
+			lastSummary = summary
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(waitPollInterval)
+	}
+
+	names := make([]string, 0, len(pending))
+	for _, st := range pending {
+		names = append(names, st.Name)
+		out.Warn("[WARN] %s is not ready (%s). Last log lines:", st.Name, st.Status)
+		logs, err := internal.ServiceLogTail(EnvFilePath(), st.Name, waitLogLines)
+		if err != nil {
+			out.Warn("  (could not read logs: %v)", err)
+			continue
+		}
+		for _, line := range logs {
+			out.Warn("  %s", line)
+		}
+	}
+	return fmt.Errorf("services not ready after %s: %s", timeout, strings.Join(names, ", "))
+}
+
+// serviceReady reports whether a compose status means the service is usable: running
+// with a passing (or no) healthcheck, or a one-shot container that exited cleanly.
+func serviceReady(status string) bool {
+	lower := strings.ToLower(status)
+	if strings.HasPrefix(lower, "exited (0)") {
+		return true
+	}
+	return strings.HasPrefix(lower, "up") &&
+		!strings.Contains(lower, "health: starting") &&
+		!strings.Contains(lower, "unhealthy")
+}
@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"leyzenctl/internal"
@@ -14,10 +18,12 @@ import (
 
 func init() {
 	statusCmd := &cobra.Command{
-		Use:          "status",
-		Aliases:      []string{"status=json"},
-		Short:        "Show the status of Leyzen Vault",
-		Long:         "Show Leyzen Vault status. Use --json, 'json' positional, or alias 'status=json' for JSON output.",
+		Use:     "status",
+		Aliases: []string{"status=json"},
+		Short:   "Show the status of Leyzen Vault",
+		Long: "Show Leyzen Vault status. Use --json, 'json' positional, or alias 'status=json' for JSON output.\n" +
+			"--stream writes one compact JSON snapshot per line (NDJSON) every --interval, for feeding dashboards.\n" +
+			"Renamed vault containers are found through VAULT_APP_NAME and VAULT_WEB_PREFIX in the env file.",
 		Args:         cobra.ArbitraryArgs,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -30,39 +36,178 @@ func init() {
 					}
 				}
 			}
-			if err := internal.EnsureDockerGeneratedFileWithWriter(cmd.OutOrStdout(), cmd.ErrOrStderr(), EnvFilePath()); err != nil {
-				return fmt.Errorf("failed to initialize configuration: %w", err)
+			watch, _ := cmd.Flags().GetBool("watch")
+			stream, _ := cmd.Flags().GetBool("stream")
+			if stream {
+				// Streaming is a JSON-only watch: one compact snapshot per line, no screen clearing
+				watch = true
+				jsonOut = true
+			}
+			interval, _ := cmd.Flags().GetDuration("interval")
+			historyFile, _ := cmd.Flags().GetString("history-file")
+			historyMax, _ := cmd.Flags().GetInt64("history-max-bytes")
+			alertMode, _ := cmd.Flags().GetBool("alert")
+			showDeps, _ := cmd.Flags().GetBool("deps")
+			only, _ := cmd.Flags().GetStringSlice("only")
+			exclude, _ := cmd.Flags().GetStringSlice("exclude")
+			sections, err := status.NewSectionFilter(only, exclude)
+			if err != nil {
+				return err
+			}
+			webhookURL, _ := cmd.Flags().GetString("webhook")
+			headerFlags, _ := cmd.Flags().GetStringArray("webhook-header")
+			webhookHeaders, err := status.ParseWebhookHeaders(headerFlags)
+			if err != nil {
+				return err
 			}
+			var thresholds status.Thresholds
+			thresholds.MaxStoragePercent, _ = cmd.Flags().GetFloat64("max-storage-percent")
+			thresholds.MaxMemoryPercent, _ = cmd.Flags().GetFloat64("max-memory-percent")
+			thresholds.MaxCPUPercent, _ = cmd.Flags().GetFloat64("max-cpu-percent")
+			thresholds.MinReplicas, _ = cmd.Flags().GetInt("min-replicas")
 
+			// Keep generation messages out of the JSON output
+			progress := cmd.OutOrStdout()
 			if jsonOut {
-				res, err := status.Collect(EnvFilePath(), 800*time.Millisecond)
-				if err != nil {
+				progress = cmd.ErrOrStderr()
+			}
+			if err := internal.EnsureDockerGeneratedFileWithWriter(progress, cmd.ErrOrStderr(), EnvFilePath()); err != nil {
+				return fmt.Errorf("failed to initialize configuration: %w", err)
+			}
+
+			render := func(res status.Result) error {
+				if stream {
+					line, err := status.MarshalJSONLine(res)
+					if err != nil {
+						return err
+					}
+					_, err = cmd.OutOrStdout().Write(line)
 					return err
 				}
-				b, err := status.MarshalJSON(res)
+				if jsonOut {
+					b, err := status.MarshalJSON(res)
+					if err != nil {
+						return err
+					}
+					fmt.Fprintln(cmd.OutOrStdout(), string(b))
+					return nil
+				}
+				status.RenderHuman(cmd.OutOrStdout(), res)
+				return nil
+			}
+
+			// collect gathers a snapshot, with the depends_on graph when --deps is set
+			collect := func() (status.Result, error) {
+				res, err := status.CollectSections(EnvFilePath(), 800*time.Millisecond, sections)
+				if err != nil || !showDeps {
+					return res, err
+				}
+				if err := status.AnnotateDependencies(&res); err != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), color.HiYellowString("[WARN] Failed to read service dependencies: %v", err))
+				}
+				return res, nil
+			}
+
+			record := func(res status.Result) {
+				if historyFile == "" {
+					return
+				}
+				if err := status.AppendHistory(historyFile, res, historyMax); err != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), color.HiYellowString("[WARN] Failed to record status history: %v", err))
+				}
+			}
+
+			// checkAlerts prints triggered alerts and reports whether any fired.
+			// In JSON mode they go to stderr so stdout stays parseable.
+			checkAlerts := func(res status.Result) bool {
+				if !alertMode {
+					return false
+				}
+				alerts := status.EvaluateAlerts(res, thresholds)
+				out := cmd.OutOrStdout()
+				if jsonOut {
+					out = cmd.ErrOrStderr()
+				}
+				for _, a := range alerts {
+					fmt.Fprintln(out, color.HiRedString("[ALERT] %s: %s", a.Check, a.Message))
+				}
+				return len(alerts) > 0
+			}
+
+			if !watch {
+				res, err := collect()
 				if err != nil {
 					return err
 				}
-				fmt.Fprintln(cmd.OutOrStdout(), string(b))
+				record(res)
+				if err := render(res); err != nil {
+					return err
+				}
+				if webhookURL != "" {
+					if err := status.PostWebhook(cmd.Context(), webhookURL, webhookHeaders, res); err != nil {
+						return err
+					}
+				}
+				if checkAlerts(res) {
+					os.Exit(2)
+				}
 				if res.Summary.OverallStatus == "critical" {
 					os.Exit(1)
 				}
 				return nil
 			}
 
-			res, err := status.Collect(EnvFilePath(), 800*time.Millisecond)
-			if err != nil {
-				return err
+			if interval < time.Second {
+				interval = time.Second
 			}
-			status.RenderHuman(cmd.OutOrStdout(), res)
-			if res.Summary.OverallStatus == "critical" {
-				os.Exit(1)
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			for {
+				res, err := collect()
+				if err != nil {
+					return err
+				}
+				record(res)
+				if !jsonOut {
+					// Clear the screen so each snapshot replaces the previous one
+					fmt.Fprint(cmd.OutOrStdout(), "\033[H\033[2J")
+				}
+				if err := render(res); err != nil {
+					return err
+				}
+				if webhookURL != "" {
+					// One failed delivery should not end a long-running watch
+					if err := status.PostWebhook(ctx, webhookURL, webhookHeaders, res); err != nil && ctx.Err() == nil {
+						fmt.Fprintln(cmd.ErrOrStderr(), color.HiYellowString("[WARN] %v", err))
+					}
+				}
+				checkAlerts(res)
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(interval):
+				}
 			}
-			return nil
 		},
 	}
 
 	statusCmd.PersistentFlags().Bool("json", false, "Output status as JSON")
+	statusCmd.Flags().BoolP("watch", "w", false, "Refresh the status continuously until interrupted")
+	statusCmd.Flags().Bool("stream", false, "Write a snapshot as one JSON line (NDJSON) every --interval until interrupted")
+	statusCmd.Flags().Duration("interval", 5*time.Second, "Refresh interval for --watch and --stream")
+	statusCmd.Flags().String("history-file", "", "Append each collected snapshot as a JSON line to this file")
+	statusCmd.Flags().Int64("history-max-bytes", status.DefaultHistoryMaxBytes, "Rotate the history file once it exceeds this size (0 disables rotation)")
+	statusCmd.Flags().StringSlice("only", nil, "Probe only these sections: "+strings.Join(status.Sections, ", "))
+	statusCmd.Flags().StringSlice("exclude", nil, "Skip probing these sections; they are reported as skipped")
+	statusCmd.Flags().Bool("deps", false, "Show what each service depends on and flag services blocked by an unhealthy dependency")
+	statusCmd.Flags().String("webhook", "", "POST each snapshot as JSON to this URL; a failed delivery exits non-zero, or only warns with --watch/--stream")
+	statusCmd.Flags().StringArray("webhook-header", nil, "Extra 'Name: Value' header for --webhook, e.g. an Authorization token (repeatable)")
+	statusCmd.Flags().Bool("alert", false, "Evaluate thresholds and exit with code 2 if any alert triggers")
+	statusCmd.Flags().Float64("max-storage-percent", 90, "Alert when data storage usage exceeds this percentage (0 disables)")
+	statusCmd.Flags().Float64("max-memory-percent", 0, "Alert when host memory usage exceeds this percentage (0 disables)")
+	statusCmd.Flags().Float64("max-cpu-percent", 0, "Alert when host CPU load exceeds this percentage (0 disables)")
+	statusCmd.Flags().Int("min-replicas", 1, "Alert when fewer web replicas are healthy (0 disables)")
 	statusCmd.FParseErrWhitelist.UnknownFlags = true
 
 	rootCmd.AddCommand(statusCmd)
@@ -4,40 +4,54 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"leyzenctl/internal"
 )
 
 func init() {
+	var skipPromote bool
+
 	stopCmd := &cobra.Command{
-		Use:          "stop [services...]",
-		Short:        "Stop the Leyzen Vault Docker stack or specific services",
+		Use:   "stop [services...]",
+		Short: "Stop the Leyzen Vault Docker stack or specific services",
+		Long: "Stops the whole stack, or only the given services.\n" +
+			"Files still in tmpfs are promoted to persistent storage first when the vault web\n" +
+			"containers go down; --skip-promote stops right away and loses those files.",
 		Args:         cobra.ArbitraryArgs,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			out := newLifecycleOutput("stop")
 			// Ensure docker-generated.yml exists before stopping
-			if err := internal.EnsureDockerGeneratedFile(EnvFilePath()); err != nil {
-				return fmt.Errorf("failed to ensure docker-generated.yml exists: %w", err)
+			if err := out.PrepareConfig(true); err != nil {
+				return err
+			}
+			if err := internal.ValidateServiceNames(EnvFilePath(), args); err != nil {
+				return err
+			}
+
+			if !skipPromote && internal.StopsVaultWeb(EnvFilePath(), args) {
+				out.PromoteFiles("stop")
 			}
 
 			if len(args) > 0 {
-				color.HiCyan("Stopping services: %s...", strings.Join(args, ", "))
-				if err := internal.RunCompose(EnvFilePath(), append([]string{"stop"}, args...)...); err != nil {
+				out.Info("Stopping services: %s...", strings.Join(args, ", "))
+				if err := out.Compose(append([]string{"stop"}, args...)...); err != nil {
 					return fmt.Errorf("failed to stop services: %w", err)
 				}
-				color.HiGreen("Successfully stopped services")
+				out.Success("Successfully stopped services")
 			} else {
-				color.HiCyan("Stopping Docker stack...")
-				if err := internal.RunCompose(EnvFilePath(), "down", "--remove-orphans"); err != nil {
+				out.Info("Stopping Docker stack...")
+				if err := out.Compose("down", "--remove-orphans"); err != nil {
 					return fmt.Errorf("failed to stop stack: %w", err)
 				}
-				color.HiGreen("Successfully stopped Docker stack")
+				out.Success("Successfully stopped Docker stack")
 			}
 			return nil
 		},
 	}
 
+	stopCmd.Flags().BoolVar(&skipPromote, "skip-promote", false, "Stop without promoting tmpfs files to persistent storage")
+
 	rootCmd.AddCommand(stopCmd)
 }
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal/ui"
+)
+
+func init() {
+	var interval time.Duration
+	var sortBy string
+
+	topCmd := &cobra.Command{
+		Use:   "top",
+		Short: "Show live CPU and memory usage of the stack's containers",
+		Long: "Shows docker stats for the project's running containers in a table refreshed every --interval.\n" +
+			"Press c, m or n to sort by CPU, memory or name, and q or Ctrl+C to exit.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+			if err := ui.ValidateTopSort(sortBy); err != nil {
+				return err
+			}
+			return ui.RunTop(cmd.Context(), EnvFilePath(), interval, sortBy, themeName)
+		},
+	}
+	topCmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "Refresh interval")
+	topCmd.Flags().StringVar(&sortBy, "sort", ui.TopSortCPU, "Sort order: cpu, mem or name")
+
+	rootCmd.AddCommand(topCmd)
+}
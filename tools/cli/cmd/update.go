@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal"
+)
+
+func init() {
+	updateCmd := &cobra.Command{
+		Use:          "update",
+		Short:        "Pull newer images and recreate only the services that changed",
+		Long:         "Pulls the images referenced by docker-generated.yml and recreates only the services whose image changed, leaving the rest of the stack running.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := internal.EnsureDockerGeneratedFileWithWriter(cmd.OutOrStdout(), cmd.ErrOrStderr(), EnvFilePath()); err != nil {
+				return fmt.Errorf("failed to initialize configuration: %w", err)
+			}
+
+			images, err := internal.ComposeServiceImages()
+			if err != nil {
+				return err
+			}
+
+			before := make(map[string]string, len(images))
+			for svc, image := range images {
+				before[svc] = internal.ImageID(image)
+			}
+
+			color.HiCyan("Pulling images...")
+			if err := internal.RunCompose(EnvFilePath(), "pull", "--ignore-buildable"); err != nil {
+				return fmt.Errorf("failed to pull images: %w", err)
+			}
+
+			var changed []string
+			for svc, image := range images {
+				if id := internal.ImageID(image); id != "" && id != before[svc] {
+					changed = append(changed, svc)
+				}
+			}
+			sort.Strings(changed)
+
+			if len(changed) == 0 {
+				color.HiGreen("All images are up to date, nothing to recreate")
+				return nil
+			}
+
+			color.HiCyan("Recreating updated services: %s...", strings.Join(changed, ", "))
+			composeArgs := append([]string{"up", "-d", "--no-deps"}, changed...)
+			if err := internal.RunCompose(EnvFilePath(), composeArgs...); err != nil {
+				return fmt.Errorf("failed to recreate services: %w", err)
+			}
+
+			color.HiGreen("Updated services: %s", strings.Join(changed, ", "))
+			return nil
+		},
+	}
+
+	rootCmd.AddCommand(updateCmd)
+}
@@ -5,9 +5,11 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 
 	"leyzenctl/internal"
+	"leyzenctl/internal/compose"
 
 	"github.com/spf13/cobra"
 )
@@ -18,7 +20,12 @@ var validateCmd = &cobra.Command{
 	Long: `Validate the .env configuration file by:
 - Comparing with env.template for missing or extra variables
 - Checking that required variables are present and non-empty
-- Verifying cryptographic secrets meet minimum length requirements (≥32 characters)`,
+- Checking values against the type declared with "# @type:" in env.template
+  (e.g. secrets of at least 32 characters, valid ports and booleans)
+- Checking that upload size limits are positive and consistent
+- Checking that SSL certificate and key files exist when HTTPS is enabled
+- Checking that encrypted secrets can be decrypted with the master key
+- Warning about variables defined more than once`,
 	SilenceUsage: true,
 	RunE:         runValidate,
 }
@@ -36,7 +43,7 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	envPath := filepath.Join(repoRoot, ".env")
 	templatePath := filepath.Join(repoRoot, "env.template")
 
-	templateVars, requiredVars, secretVars, err := parseTemplate(templatePath)
+	templateVars, requiredVars, err := parseTemplate(templatePath)
 	if err != nil {
 		return fmt.Errorf("failed to parse env.template: %w", err)
 	}
@@ -49,6 +56,20 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	errors := []string{}
 	warnings := []string{}
 
+	if envFile, err := internal.LoadEnvFile(envPath); err == nil {
+		for _, key := range envFile.Duplicates() {
+			warnings = append(warnings, fmt.Sprintf("Variable defined more than once (last value wins): %s", key))
+		}
+		// Check the decrypted values of secrets stored encrypted
+		if decrypted, err := envFile.DecryptedPairs(); err != nil {
+			errors = append(errors, err.Error())
+		} else {
+			for key, value := range decrypted {
+				envVars[key] = value
+			}
+		}
+	}
+
 	orchestratorEnabled := true
 	if val, exists := envVars["ORCHESTRATOR_ENABLED"]; exists {
 		val = strings.ToLower(strings.TrimSpace(val))
@@ -66,18 +87,25 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	for _, secretVar := range secretVars {
-		value, exists := envVars[secretVar]
-		if exists && strings.TrimSpace(value) != "" {
-			if len(value) < 32 {
-				errors = append(errors, fmt.Sprintf(
-					"Secret %s must be at least 32 characters long (got %d characters). Generate with: openssl rand -hex 32",
-					secretVar, len(value),
-				))
-			}
+	typedKeys := make([]string, 0, len(envVars))
+	for key := range envVars {
+		typedKeys = append(typedKeys, key)
+	}
+	sort.Strings(typedKeys)
+	for _, key := range typedKeys {
+		if _, err := internal.ValidateEnvValue(envPath, key, envVars[key]); err != nil {
+			errors = append(errors, fmt.Sprintf("Invalid value for %s: %v", key, err))
 		}
 	}
 
+	if err := internal.ValidateSizeLimits(envVars); err != nil {
+		errors = append(errors, err.Error())
+	}
+
+	if internal.IsTrue(envVars["ENABLE_HTTPS"]) {
+		errors = append(errors, compose.ValidateSSLFiles(envVars["SSL_CERT_PATH"], envVars["SSL_KEY_PATH"], envVars["SSL_CHAIN_PATH"], repoRoot)...)
+	}
+
 	for templateVar := range templateVars {
 		if _, exists := envVars[templateVar]; !exists {
 			if !templateVars[templateVar].optional {
@@ -122,14 +150,13 @@ type varInfo struct {
 	optional bool
 }
 
-func parseTemplate(path string) (map[string]varInfo, []string, []string, error) {
+func parseTemplate(path string) (map[string]varInfo, []string, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, err
 	}
 
 	vars := make(map[string]varInfo)
-	secrets := []string{}
 
 	lines := strings.Split(string(content), "\n")
 
@@ -150,10 +177,6 @@ func parseTemplate(path string) (map[string]varInfo, []string, []string, error)
 				isCommented
 
 			vars[varName] = varInfo{optional: isOptional}
-
-			if varName == "SECRET_KEY" {
-				secrets = append(secrets, varName)
-			}
 		}
 	}
 
@@ -161,7 +184,7 @@ func parseTemplate(path string) (map[string]varInfo, []string, []string, error)
 		"SECRET_KEY",
 	}
 
-	return vars, required, secrets, nil
+	return vars, required, nil
 }
 
 func parseEnv(path string) (map[string]string, error) {
@@ -174,18 +197,9 @@ func parseEnv(path string) (map[string]string, error) {
 	lines := strings.Split(string(content), "\n")
 
 	for _, line := range lines {
-		line = strings.TrimSpace(line)
-
-		if strings.HasPrefix(line, "#") || line == "" {
-			continue
-		}
-
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) == 2 {
-			key := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
-			value = strings.Trim(value, `"'`)
-			vars[key] = value
+		entry := internal.ParseEnvLine(line)
+		if entry.IsPair {
+			vars[entry.Key] = entry.Value
 		}
 	}
 
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"leyzenctl/internal/version"
+)
+
+const releasesURL = "https://github.com/3xpyth0n/leyzen-vault/releases/tag/"
+
+// refreshRelease bypasses the cached latest-release lookup (version --refresh).
+var refreshRelease bool
+
+func init() {
+	var check bool
+	var asJSON bool
+
+	versionCmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print version information",
+		Long: "Prints the leyzenctl version. With --check, the latest GitHub release is looked up and\n" +
+			"compared with this build; when GitHub cannot be reached only the current version is printed.\n" +
+			"The lookup is cached for 6 hours; --refresh forces a fresh one.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format := "text"
+			if asJSON {
+				format = "json"
+			}
+			if !check {
+				printVersion(format)
+				return nil
+			}
+			printVersionCheck(format)
+			return nil
+		},
+	}
+	versionCmd.Flags().BoolVar(&check, "check", false, "Check whether a newer release is available")
+	versionCmd.Flags().BoolVar(&asJSON, "json", false, "Output as JSON")
+	versionCmd.Flags().BoolVar(&refreshRelease, "refresh", false, "Ignore the cached latest release and query GitHub again")
+
+	rootCmd.AddCommand(versionCmd)
+}
+
+// printVersionCheck prints the current version and whether the latest release is newer.
+// Failing to reach GitHub is not an error: the latest release is simply left out.
+func printVersionCheck(format string) {
+	current := version.Version
+	latest := latestStable()
+	updateAvailable := latest != "" && isNewerVersion(latest, current)
+
+	if format == "json" {
+		type payload struct {
+			Version         string `json:"version"`
+			LatestStable    string `json:"latestStable,omitempty"`
+			UpdateAvailable bool   `json:"updateAvailable"`
+			ReleaseURL      string `json:"releaseUrl,omitempty"`
+		}
+		p := payload{Version: current, LatestStable: latest, UpdateAvailable: updateAvailable}
+		if updateAvailable {
+			p.ReleaseURL = releasesURL + latest
+		}
+		b, _ := json.Marshal(p)
+		fmt.Println(string(b))
+		return
+	}
+
+	fmt.Printf("leyzenctl %s\n", current)
+	switch {
+	case latest == "":
+		return
+	case updateAvailable:
+		color.HiYellow("Update available: %s (%s%s)", latest, releasesURL, latest)
+	default:
+		color.HiGreen("Up to date (latest release: %s)", latest)
+	}
+}
+
+// isNewerVersion reports whether release is a higher version than current. Builds
+// without a numeric version (dev, nightly) are never considered outdated.
+func isNewerVersion(release, current string) bool {
+	r, ok := parseVersion(release)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	for i := range r {
+		if r[i] != c[i] {
+			return r[i] > c[i]
+		}
+	}
+	return false
+}
+
+// parseVersion extracts major, minor and patch from tags such as v1.2.3 or 1.2.3-rc1.
+func parseVersion(v string) ([3]int, bool) {
+	var parts [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	fields := strings.Split(v, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parts, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
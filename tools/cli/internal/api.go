@@ -3,15 +3,35 @@ package internal
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const apiTimeout = 5 * time.Minute
 
+// StopsVaultWeb reports whether stopping services takes down a vault web container,
+// which holds uploads in tmpfs. No services means the whole stack.
+func StopsVaultWeb(envFile string, services []string) bool {
+	if len(services) == 0 {
+		return true
+	}
+	app, webPrefix := VaultServiceNames(envFile)
+	for _, service := range services {
+		if service == app || strings.HasPrefix(service, webPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // PrepareRotation calls the prepare-rotation endpoint on the active vault container
 // to promote all files from tmpfs to persistent storage before shutdown.
 func PrepareRotation(envFile string) error {
@@ -42,7 +62,8 @@ func PrepareRotation(envFile string) error {
 		token = "placeholder"
 	}
 
-	// Use docker exec to call the API from within the vault container using Python
+	// Use docker exec to call the API from within the vault container using Python.
+	// Exit code 75 marks failures worth retrying (server errors, refused connections, timeouts).
 	pythonScript := fmt.Sprintf(`
 import sys
 import json
@@ -60,29 +81,82 @@ try:
     with urllib.request.urlopen(req, timeout=300) as response:
         result = json.loads(response.read().decode('utf-8'))
         if not result.get('overall_success', False):
+            print("promotion reported failures", file=sys.stderr)
             sys.exit(1)
+except urllib.error.HTTPError as e:
+    print(f"HTTP {e.code}: {e.reason}", file=sys.stderr)
+    sys.exit(75 if e.code >= 500 else 1)
+except (urllib.error.URLError, TimeoutError, ConnectionError) as e:
+    print(f"Error: {e}", file=sys.stderr)
+    sys.exit(75)
 except Exception as e:
     print(f"Error: {e}", file=sys.stderr)
     sys.exit(1)
 `, token)
 
-	ctx, cancel := context.WithTimeout(context.Background(), apiTimeout)
+	attempts := prepareRotationAttempts()
+	backoff := prepareRotationBackoff
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		retryable, err := runPrepareRotation(activeContainer, pythonScript)
+		if err == nil {
+			return nil
+		}
+		if !retryable {
+			return fmt.Errorf("%w (attempt %d/%d)", err, attempt, attempts)
+		}
+		lastErr = err
+		if attempt < attempts {
+			time.Sleep(backoff)
+			backoff = min(backoff*2, prepareRotationMaxBackoff)
+		}
+	}
+	return fmt.Errorf("%w (gave up after %d attempts)", lastErr, attempts)
+}
+
+const (
+	defaultPrepareRotationAttempts = 3
+	prepareRotationBackoff         = time.Second
+	prepareRotationMaxBackoff      = 30 * time.Second
+	retryableExitCode              = 75
+)
+
+// prepareRotationAttempts reads LEYZEN_ROTATION_ATTEMPTS (default 3, minimum 1).
+func prepareRotationAttempts() int {
+	if raw := strings.TrimSpace(os.Getenv("LEYZEN_ROTATION_ATTEMPTS")); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n >= 1 {
+			return n
+		}
+	}
+	return defaultPrepareRotationAttempts
+}
+
+// runPrepareRotation makes one prepare-rotation call and reports whether a failure is
+// transient. A container that is gone or stopped is never worth retrying.
+func runPrepareRotation(container, pythonScript string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutOr(apiTimeout))
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "docker", "exec", activeContainer, "python3", "-c", pythonScript)
+	cmd := exec.CommandContext(ctx, "docker", "exec", container, "python3", "-c", pythonScript)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	if err := cmd.Run(); err != nil {
-		if strings.Contains(stderr.String(), "No such container") ||
-			strings.Contains(stderr.String(), "is not running") {
-			return fmt.Errorf("container %s is not running", activeContainer)
-		}
-		return fmt.Errorf("prepare-rotation failed: %w - %s", err, stderr.String())
+	err := cmd.Run()
+	if err == nil {
+		return false, nil
+	}
+	if strings.Contains(stderr.String(), "No such container") ||
+		strings.Contains(stderr.String(), "is not running") {
+		return false, fmt.Errorf("container %s is not running", container)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return true, fmt.Errorf("prepare-rotation timed out after %s", timeoutOr(apiTimeout))
 	}
 
-	return nil
+	var exitErr *exec.ExitError
+	retryable := errors.As(err, &exitErr) && exitErr.ExitCode() == retryableExitCode
+	return retryable, fmt.Errorf("prepare-rotation failed: %w - %s", err, strings.TrimSpace(stderr.String()))
 }
 
 // getActiveContainer finds the active vault container (running and healthy)
@@ -94,9 +168,10 @@ func getActiveContainer(envFile string) (string, error) {
 	}
 
 	// Parse container names
+	_, webPrefix := VaultServiceNames(envFile)
 	containers := strings.Fields(output)
 	for _, name := range containers {
-		if strings.HasPrefix(name, "vault_web") {
+		if strings.HasPrefix(ServiceFromContainerName(name), webPrefix) {
 			return name, nil
 		}
 	}
@@ -104,7 +179,8 @@ func getActiveContainer(envFile string) (string, error) {
 	return "", nil // No active container found (not an error)
 }
 
-// getInternalAPIToken retrieves the INTERNAL_API_TOKEN from environment or .env file
+// getInternalAPIToken retrieves the INTERNAL_API_TOKEN from the environment or the env
+// file. When it is not set, the token the services derive from SECRET_KEY is returned.
 func getInternalAPIToken(envFile string) (string, error) {
 	// First check environment variable
 	if token := os.Getenv("INTERNAL_API_TOKEN"); token != "" {
@@ -122,11 +198,23 @@ func getInternalAPIToken(envFile string) (string, error) {
 		return "", fmt.Errorf("load env file: %w", err)
 	}
 
-	token, found := envFileData.Get("INTERNAL_API_TOKEN")
-	if !found || token == "" {
-		// Token not set - this is not necessarily an error, it may be auto-generated
-		return "", nil
+	pairs, err := envFileData.DecryptedPairs()
+	if err != nil {
+		return "", err
+	}
+	if token := pairs["INTERNAL_API_TOKEN"]; token != "" {
+		return token, nil
+	}
+	if secretKey := pairs["SECRET_KEY"]; secretKey != "" {
+		return deriveInternalAPIToken(secretKey), nil
 	}
+	return "", nil
+}
 
-	return token, nil
+// deriveInternalAPIToken mirrors common.token_utils.derive_internal_api_token, which the
+// vault and the orchestrator use when INTERNAL_API_TOKEN is not set.
+func deriveInternalAPIToken(secretKey string) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte("internal-api-token-v1"))
+	return hex.EncodeToString(mac.Sum(nil))
 }
@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDeriveInternalAPITokenMatchesServices(t *testing.T) {
+	// hmac.new(b"k" * 32, b"internal-api-token-v1", hashlib.sha256).hexdigest()
+	want := "7a0d8184440eaff4163a0db80e196a80dc167f90aa641d29505b7b074f69f04f"
+	if got := deriveInternalAPIToken(strings.Repeat("k", 32)); got != want {
+		t.Errorf("deriveInternalAPIToken = %s, want %s", got, want)
+	}
+}
+
+func TestStopsVaultWebUsesCustomNames(t *testing.T) {
+	root := chdirRepo(t, "")
+	envFile := writeRepoFile(t, root, ".env", "VAULT_APP_NAME=vault\nVAULT_WEB_PREFIX=web\n")
+
+	tests := []struct {
+		services []string
+		want     bool
+	}{
+		{nil, true},
+		{[]string{"web2"}, true},
+		{[]string{"postgres", "vault"}, true},
+		{[]string{"vault_web1"}, false},
+		{[]string{"postgres", "haproxy"}, false},
+	}
+	for _, tt := range tests {
+		if got := StopsVaultWeb(envFile, tt.services); got != tt.want {
+			t.Errorf("StopsVaultWeb(%v) = %v, want %v", tt.services, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const backupTimeout = 30 * time.Minute
+
+// createBackupScript runs DatabaseBackupService.create_backup inside the vault container.
+// argv[1] is the storage type ("local" or "both").
+const createBackupScript = `
+import sys
+from vault.app import create_app
+app = create_app()
+with app.app_context():
+    from vault.services.database_backup_service import DatabaseBackupService
+    secret_key = app.config.get("SECRET_KEY", "")
+    if not secret_key:
+        print("SECRET_KEY is not configured in the container", file=sys.stderr)
+        sys.exit(1)
+    storage_type = sys.argv[1]
+    print(f"Creating backup (storage: {storage_type})...", flush=True)
+    try:
+        backup = DatabaseBackupService(secret_key, app).create_backup(backup_type="manual", storage_type=storage_type)
+    except Exception as e:
+        print(f"Backup failed: {e}", file=sys.stderr)
+        sys.exit(1)
+    print(f"Backup {backup.id} completed", flush=True)
+    print(f"  Location: {backup.storage_location}", flush=True)
+    print(f"  Size: {backup.size_bytes} bytes", flush=True)
+`
+
+// listBackupsScript prints the backups known to DatabaseBackupService as JSON, newest first.
+const listBackupsScript = `
+import json, sys
+from vault.app import create_app
+app = create_app()
+with app.app_context():
+    from vault.services.database_backup_service import DatabaseBackupService
+    secret_key = app.config.get("SECRET_KEY", "")
+    if not secret_key:
+        print("SECRET_KEY is not configured in the container", file=sys.stderr)
+        sys.exit(1)
+    backups = DatabaseBackupService(secret_key, app).list_backups() or []
+    out = [{
+        "id": str(b.get("id") or ""),
+        "created_at": str(b.get("created_at") or ""),
+        "storage_location": str(b.get("storage_location") or ""),
+        "size_bytes": int(b.get("size_bytes") or 0),
+    } for b in backups]
+    out.sort(key=lambda b: b["created_at"], reverse=True)
+    print(json.dumps(out))
+`
+
+// restoreBackupScript restores the backup whose id is argv[1].
+const restoreBackupScript = `
+import sys
+from vault.app import create_app
+app = create_app()
+with app.app_context():
+    from vault.services.database_backup_service import DatabaseBackupService
+    secret_key = app.config.get("SECRET_KEY", "")
+    if not secret_key:
+        print("SECRET_KEY is not configured in the container", file=sys.stderr)
+        sys.exit(1)
+    backup_id = sys.argv[1]
+    svc = DatabaseBackupService(secret_key, app)
+    backup_file, _ = svc._find_backup_by_id_in_storage(backup_id)
+    if not backup_file or not backup_file.exists():
+        print(f"Backup not found: {backup_id}", file=sys.stderr)
+        sys.exit(2)
+    print(f"Restoring from {backup_file}...", flush=True)
+    try:
+        svc.restore_backup_radical(backup_file, backup_id)
+    except Exception as e:
+        print(f"Restore failed: {e}", file=sys.stderr)
+        sys.exit(1)
+    print(f"Backup {backup_id} restored", flush=True)
+`
+
+// BackupInfo describes a database backup reported by the vault application.
+type BackupInfo struct {
+	ID              string `json:"id"`
+	CreatedAt       string `json:"created_at"`
+	StorageLocation string `json:"storage_location"`
+	SizeBytes       int64  `json:"size_bytes"`
+}
+
+// ListBackups returns the local and external backups known to the vault application.
+func ListBackups(envFile string) ([]BackupInfo, error) {
+	container, err := getHealthyContainer(envFile)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutOr(apiTimeout))
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", "exec", container, "python3", "-c", listBackupsScript)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("list backups in %s: %w - %s", container, err, strings.TrimSpace(stderr.String()))
+	}
+
+	// The app may log to stdout during startup; the JSON payload is the last line
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	var backups []BackupInfo
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &backups); err != nil {
+		return nil, fmt.Errorf("parse backup list: %w", err)
+	}
+	return backups, nil
+}
+
+// RestoreBackup restores the given backup in a healthy vault container and streams its output.
+// The restore replaces the current database.
+func RestoreBackup(envFile, backupID string, stdout, stderr io.Writer) error {
+	container, err := getHealthyContainer(envFile)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutOr(backupTimeout))
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "docker", "exec", container, "python3", "-u", "-c", restoreBackupScript, backupID)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("restore in %s failed: %w", container, err)
+	}
+	return nil
+}
+
+// CreateBackup triggers a database backup in a healthy vault container and streams
+// its output. With toS3 set, the backup is also uploaded to external storage.
+func CreateBackup(envFile string, toS3 bool, stdout, stderr io.Writer) error {
+	container, err := getHealthyContainer(envFile)
+	if err != nil {
+		return err
+	}
+
+	storageType := "local"
+	if toS3 {
+		storageType = "both"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutOr(backupTimeout))
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "docker", "exec", container, "python3", "-u", "-c", createBackupScript, storageType)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("backup in %s failed: %w", container, err)
+	}
+	return nil
+}
+
+// getHealthyContainer returns a running vault container whose healthcheck passes,
+// or an error when none is available.
+func getHealthyContainer(envFile string) (string, error) {
+	output, err := DockerComposePS(envFile, "--filter", "status=running", "--format", "{{.Name}}")
+	if err != nil {
+		return "", fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	// Numbered web replicas run under the orchestrator, the single app container otherwise
+	app, webPrefix := VaultServiceNames(envFile)
+	for _, name := range strings.Fields(output) {
+		service := ServiceFromContainerName(name)
+		if service != app && !strings.HasPrefix(service, webPrefix) {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		out, err := exec.CommandContext(ctx, "docker", "inspect", "--format",
+			"{{if .State.Health}}{{.State.Health.Status}}{{else}}{{.State.Status}}{{end}}", name).Output()
+		cancel()
+		if err != nil {
+			continue
+		}
+		// Containers without a healthcheck report their plain state
+		if state := strings.TrimSpace(string(out)); state == "healthy" || state == "running" {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no healthy vault container is running; start the stack with 'leyzenctl start' and retry")
+}
@@ -2,19 +2,37 @@ package compose
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
-// BuildComposeManifest generates the Docker Compose manifest
+const redisDataVolume = "leyzen-vault-redis-data"
+
+// OptionalVolumeNames lists the volumes declared only while their service is enabled,
+// such as the Redis data kept for CACHE_BACKEND=redis. Names are before ScopedName.
+var OptionalVolumeNames = []string{redisDataVolume}
+
+// SecretsEnvFile is the env file, next to docker-generated.yml, that hands the decrypted
+// secrets to the services at run time so they never appear in the manifest.
+const SecretsEnvFile = "docker-generated.secrets.env"
+
+// BuildComposeManifest generates the Docker Compose manifest. The same env always
+// yields byte-identical output (see Manifest), so the generated file only changes
+// when the configuration does. decryptedSecrets holds the values stored encrypted
+// in the env file, which services reading it through env_file would otherwise get
+// as ciphertext. They are returned as the contents of SecretsEnvFile, which the
+// caller writes, along with every environment entry that embeds one of them.
 func BuildComposeManifest(
 	env map[string]string,
 	webContainers []string,
 	sslCertBundlePath string,
 	envFilePath string,
-) ([]byte, error) {
+	decryptedSecrets map[string]string,
+) ([]byte, map[string]string, error) {
 	manifest := Manifest{
 		Services: make(map[string]ServiceDefinition),
 		Volumes:  make(map[string]VolumeDefinition),
@@ -26,10 +44,16 @@ func BuildComposeManifest(
 	// PostgreSQL
 	postgresService, err := buildPostgresService(env)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build postgres service: %w", err)
+		return nil, nil, fmt.Errorf("failed to build postgres service: %w", err)
 	}
 	manifest.Services[PostgresContainerName] = postgresService
 
+	// Redis cache (only if enabled)
+	if isRedisCacheEnabled(env) {
+		manifest.Services[RedisContainerName] = buildRedisService(env)
+		manifest.Volumes[RedisDataVolumeName] = VolumeDefinition{Name: scopedName(env, redisDataVolume)}
+	}
+
 	// Vault Services
 	vaultServices := buildVaultServices(env, webContainers, envFilePath)
 	for name, service := range vaultServices {
@@ -42,19 +66,141 @@ func BuildComposeManifest(
 		manifest.Services[name] = service
 	}
 
+	secretsEnv := extractSecrets(manifest.Services, decryptedSecrets)
+
+	// Logging applies to every service so host log files stay bounded
+	logging := buildLogging(env)
+	for name, service := range manifest.Services {
+		service.Logging = logging
+		manifest.Services[name] = service
+	}
+
+	// Labels (yaml.v3 sorts map keys, so output stays diff-stable)
+	applyLabels(env, manifest.Services, webContainers)
+
 	// Volumes
 	postgresVolName := getEnv(env, "POSTGRES_DATA_VOLUME", PostgresDataVolumeName)
-	manifest.Volumes[postgresVolName] = VolumeDefinition{Name: "leyzen-vault-postgres-data"}
+	manifest.Volumes[postgresVolName] = VolumeDefinition{Name: scopedName(env, "leyzen-vault-postgres-data")}
 
-	manifest.Volumes[VaultDataSourceVolume] = VolumeDefinition{Name: "leyzen-vault-data-source"}
+	manifest.Volumes[VaultDataSourceVolume] = VolumeDefinition{Name: scopedName(env, "leyzen-vault-data-source")}
 
-	manifest.Volumes["orchestrator-logs"] = VolumeDefinition{Name: "leyzen-orchestrator-logs"}
+	manifest.Volumes["orchestrator-logs"] = VolumeDefinition{Name: scopedName(env, "leyzen-orchestrator-logs")}
 
 	// Networks
-	manifest.Networks[VaultNetworkName] = NetworkDefinition{Driver: "bridge", Name: "leyzen-vault-net"}
-	manifest.Networks[ControlNetworkName] = NetworkDefinition{Driver: "bridge", Name: "leyzen-control-net"}
+	manifest.Networks[VaultNetworkName] = NetworkDefinition{Driver: "bridge", Name: scopedName(env, "leyzen-vault-net")}
+	manifest.Networks[ControlNetworkName] = NetworkDefinition{Driver: "bridge", Name: scopedName(env, "leyzen-control-net")}
+
+	manifestBytes, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if vaultDependsOnCondition(env) != "service_healthy" {
+		manifestBytes = append([]byte(relaxedDependsOnComment), manifestBytes...)
+	}
+	return manifestBytes, secretsEnv, nil
+}
+
+// extractSecrets returns the contents of SecretsEnvFile: the decrypted secrets, plus the
+// environment entries whose value embeds one (such as a database URI), which are removed
+// from the services. Services reading the env file, or losing an entry, get SecretsEnvFile
+// as a later env_file so its values override the ciphertext. Explicit environment entries
+// still take precedence over it, as they do over the env file.
+func extractSecrets(services map[string]ServiceDefinition, decryptedSecrets map[string]string) map[string]string {
+	if len(decryptedSecrets) == 0 {
+		return nil
+	}
+	secretsEnv := make(map[string]string, len(decryptedSecrets))
+	for key, value := range decryptedSecrets {
+		secretsEnv[key] = value
+	}
+
+	for name, service := range services {
+		moved := false
+		for key, value := range service.Environment {
+			if !embedsSecret(value, decryptedSecrets) {
+				continue
+			}
+			// The file is shared, so an entry only moves if no other service needs another value
+			if existing, taken := secretsEnv[key]; taken && existing != value {
+				continue
+			}
+			secretsEnv[key] = value
+			delete(service.Environment, key)
+			moved = true
+		}
+		if len(service.Environment) == 0 {
+			service.Environment = nil
+		}
+		if moved || len(service.EnvFile) > 0 {
+			service.EnvFile = append(service.EnvFile, SecretsEnvFile)
+		}
+		services[name] = service
+	}
+	return secretsEnv
+}
+
+func embedsSecret(value string, secrets map[string]string) bool {
+	for _, secret := range secrets {
+		if secret != "" && strings.Contains(value, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+const relaxedDependsOnComment = `# VAULT_DEPENDS_ON_CONDITION=service_started: the vault replicas only wait for
+# haproxy, postgres and redis to be running, not healthy. The stack cannot hang on
+# a failing healthcheck, but the replicas may start before the database accepts
+# connections (they restart until it does) and serve a degraded stack meanwhile.
+
+`
+
+// ScopedName prefixes a container, volume or network name with the compose project name,
+// so stacks started with different -p values do not share them. Without a project name
+// the name is returned unchanged.
+func ScopedName(project, name string) string {
+	if project == "" {
+		return name
+	}
+	return project + "-" + name
+}
 
-	return yaml.Marshal(manifest)
+// scopedName scopes name to COMPOSE_PROJECT_NAME from env.
+func scopedName(env map[string]string, name string) string {
+	return ScopedName(strings.TrimSpace(env["COMPOSE_PROJECT_NAME"]), name)
+}
+
+// Default names of the vault services. VAULT_APP_NAME and VAULT_WEB_PREFIX in the env
+// file override them for stacks whose containers were renamed.
+const (
+	DefaultVaultAppName   = "vault_app"
+	DefaultVaultWebPrefix = "vault_web"
+)
+
+// VaultAppName returns the service name of the single vault container run without the
+// orchestrator.
+func VaultAppName(env map[string]string) string {
+	if name := strings.TrimSpace(env["VAULT_APP_NAME"]); name != "" {
+		return name
+	}
+	return DefaultVaultAppName
+}
+
+// VaultWebPrefix returns the prefix of the numbered web replicas run by the orchestrator.
+func VaultWebPrefix(env map[string]string) string {
+	if prefix := strings.TrimSpace(env["VAULT_WEB_PREFIX"]); prefix != "" {
+		return prefix
+	}
+	return DefaultVaultWebPrefix
+}
+
+// vaultDependsOnCondition returns the depends_on condition of the vault replicas:
+// service_healthy unless VAULT_DEPENDS_ON_CONDITION relaxes it to service_started.
+func vaultDependsOnCondition(env map[string]string) string {
+	if strings.EqualFold(getEnv(env, "VAULT_DEPENDS_ON_CONDITION", ""), "service_started") {
+		return "service_started"
+	}
+	return "service_healthy"
 }
 
 func isOrchestratorEnabled(env map[string]string) bool {
@@ -65,6 +211,32 @@ func isOrchestratorEnabled(env map[string]string) bool {
 	return val == "true" || val == "1" || val == "yes" || val == "on"
 }
 
+// getBool reads a boolean toggle, accepting true/1/yes/on and false/0/no/off.
+func getBool(env map[string]string, key string, defaultVal bool) bool {
+	switch strings.ToLower(getEnv(env, key, "")) {
+	case "true", "1", "yes", "on":
+		return true
+	case "false", "0", "no", "off":
+		return false
+	}
+	return defaultVal
+}
+
+// hardenService drops all capabilities except those listed and forbids privilege escalation.
+// It is a no-op when VAULT_HARDEN is disabled.
+func hardenService(env map[string]string, service *ServiceDefinition, capAdd ...string) {
+	if !getBool(env, "VAULT_HARDEN", true) {
+		return
+	}
+	service.CapDrop = []string{"ALL"}
+	service.CapAdd = capAdd
+	service.SecurityOpt = []string{"no-new-privileges:true"}
+}
+
+func isRedisCacheEnabled(env map[string]string) bool {
+	return strings.EqualFold(getEnv(env, "CACHE_BACKEND", ""), "redis")
+}
+
 func getEnv(env map[string]string, key, defaultVal string) string {
 	if val, ok := env[key]; ok && strings.TrimSpace(val) != "" {
 		return strings.TrimSpace(val)
@@ -87,6 +259,160 @@ func parsePort(env map[string]string, key string, defaultVal int) int {
 	return val
 }
 
+// getDuration reads a compose duration such as "30s" or "2m". Bare integers are treated as seconds.
+func getDuration(env map[string]string, key, defaultVal string) string {
+	valStr := getEnv(env, key, "")
+	if valStr == "" {
+		return defaultVal
+	}
+	if n, err := strconv.Atoi(valStr); err == nil {
+		if n < 0 {
+			return defaultVal
+		}
+		return fmt.Sprintf("%ds", n)
+	}
+	if d, err := time.ParseDuration(valStr); err != nil || d < 0 {
+		return defaultVal
+	}
+	return valStr
+}
+
+func getPositiveInt(env map[string]string, key string, defaultVal int) int {
+	val, err := strconv.Atoi(getEnv(env, key, ""))
+	if err != nil || val < 1 {
+		return defaultVal
+	}
+	return val
+}
+
+// MemLimitPattern matches compose memory sizes such as 512m, 1.5g or 1gb.
+var MemLimitPattern = regexp.MustCompile(`(?i)^[0-9]+(\.[0-9]+)?([bkmg]|[kmg]b)?$`)
+
+// getMemLimit returns the memory limit for key, or an empty string when unset or invalid.
+func getMemLimit(env map[string]string, key string) string {
+	valStr := getEnv(env, key, "")
+	if !MemLimitPattern.MatchString(valStr) {
+		return ""
+	}
+	return strings.ToLower(valStr)
+}
+
+// getCPULimit returns the CPU limit for key, or 0 when unset or invalid.
+func getCPULimit(env map[string]string, key string) float64 {
+	val, err := strconv.ParseFloat(getEnv(env, key, ""), 64)
+	if err != nil || val <= 0 {
+		return 0
+	}
+	return val
+}
+
+// ConfigWarnings lists the env values the builder ignores because they are invalid,
+// so generation can report them instead of silently falling back to the defaults.
+func ConfigWarnings(env map[string]string) []string {
+	var warnings []string
+	if raw := getEnv(env, "VAULT_MEM_LIMIT", ""); raw != "" && getMemLimit(env, "VAULT_MEM_LIMIT") == "" {
+		warnings = append(warnings, fmt.Sprintf("VAULT_MEM_LIMIT=%q is not a memory size such as 512m or 2g; no memory limit applied", raw))
+	}
+	if raw := getEnv(env, "VAULT_CPU_LIMIT", ""); raw != "" && getCPULimit(env, "VAULT_CPU_LIMIT") == 0 {
+		warnings = append(warnings, fmt.Sprintf("VAULT_CPU_LIMIT=%q is not a positive number of CPUs; no CPU limit applied", raw))
+	}
+	if raw := getEnv(env, "VAULT_DEPENDS_ON_CONDITION", ""); raw != "" && !strings.EqualFold(raw, "service_healthy") && !strings.EqualFold(raw, "service_started") {
+		warnings = append(warnings, fmt.Sprintf("VAULT_DEPENDS_ON_CONDITION=%q is neither service_healthy nor service_started; service_healthy applied", raw))
+	}
+	warnings = append(warnings, haproxyWarnings(env)...)
+	return warnings
+}
+
+// buildLogging returns the logging block shared by all services. The json-file and
+// local drivers rotate at 10m x 3 files unless DOCKER_LOG_MAX_SIZE/DOCKER_LOG_MAX_FILE
+// override it; other drivers only receive the options that are set explicitly.
+// DOCKER_LOG_OPTIONS adds extra key=value pairs separated by commas.
+func buildLogging(env map[string]string) *LoggingDefinition {
+	driver := getEnv(env, "DOCKER_LOG_DRIVER", "json-file")
+	options := make(map[string]string)
+
+	rotates := driver == "json-file" || driver == "local"
+	maxSize := getEnv(env, "DOCKER_LOG_MAX_SIZE", "")
+	maxFile := getEnv(env, "DOCKER_LOG_MAX_FILE", "")
+	if rotates {
+		if maxSize == "" {
+			maxSize = "10m"
+		}
+		if maxFile == "" {
+			maxFile = "3"
+		}
+	}
+	if maxSize != "" {
+		options["max-size"] = maxSize
+	}
+	if maxFile != "" {
+		options["max-file"] = maxFile
+	}
+
+	for _, pair := range strings.Split(getEnv(env, "DOCKER_LOG_OPTIONS", ""), ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		options[key] = strings.TrimSpace(value)
+	}
+
+	if len(options) == 0 {
+		options = nil
+	}
+	return &LoggingDefinition{Driver: driver, Options: options}
+}
+
+// serviceRole returns the com.leyzen.role label value for a service.
+func serviceRole(name string, webContainers []string) string {
+	for _, web := range webContainers {
+		if name == web {
+			return "vault"
+		}
+	}
+	switch name {
+	case PostgresContainerName:
+		return "database"
+	case HAProxyContainerName:
+		return "proxy"
+	case RedisContainerName:
+		return "cache"
+	}
+	return name
+}
+
+// applyLabels sets the baseline labels on every service, then user labels from
+// LABEL_<service>_<key>=value entries. LABEL_all_<key> applies to every service.
+func applyLabels(env map[string]string, services map[string]ServiceDefinition, webContainers []string) {
+	for name, service := range services {
+		labels := map[string]string{
+			LabelRole:    serviceRole(name, webContainers),
+			LabelManaged: "true",
+		}
+		// Service-specific labels are applied last so they override LABEL_all_ ones
+		for _, target := range []string{UserLabelAllName, name} {
+			prefix := UserLabelPrefix + target + "_"
+			for key, value := range env {
+				if len(key) > len(prefix) && strings.EqualFold(key[:len(prefix)], prefix) {
+					labels[key[len(prefix):]] = strings.TrimSpace(value)
+				}
+			}
+		}
+		service.Labels = labels
+		services[name] = service
+	}
+}
+
+// MissingVariableError reports a variable the stack cannot be generated without.
+type MissingVariableError struct {
+	Name string
+}
+
+func (e *MissingVariableError) Error() string {
+	return e.Name + " is required in environment"
+}
+
 func buildPostgresService(env map[string]string) (ServiceDefinition, error) {
 	db := getEnv(env, "POSTGRES_DB", "leyzen_vault")
 	user := getEnv(env, "POSTGRES_USER", "leyzen")
@@ -95,12 +421,12 @@ func buildPostgresService(env map[string]string) (ServiceDefinition, error) {
 	dataVol := getEnv(env, "POSTGRES_DATA_VOLUME", PostgresDataVolumeName)
 
 	if pass == "" {
-		return ServiceDefinition{}, fmt.Errorf("POSTGRES_PASSWORD is required in environment")
+		return ServiceDefinition{}, &MissingVariableError{Name: "POSTGRES_PASSWORD"}
 	}
 
-	return ServiceDefinition{
+	service := ServiceDefinition{
 		Image:         "postgres:16-alpine",
-		ContainerName: PostgresContainerName,
+		ContainerName: scopedName(env, PostgresContainerName),
 		Restart:       "on-failure",
 		Environment: map[string]string{
 			"POSTGRES_DB":               db,
@@ -118,13 +444,43 @@ func buildPostgresService(env map[string]string) (ServiceDefinition, error) {
 				"CMD-SHELL",
 				"pg_isready -U ${POSTGRES_USER:-leyzen} -d postgres || exit 1",
 			},
-			Interval:    "2s",
+			Interval:    getDuration(env, "POSTGRES_HEALTHCHECK_INTERVAL", "2s"),
 			Timeout:     "5s",
+			Retries:     getPositiveInt(env, "POSTGRES_HEALTHCHECK_RETRIES", 10),
+			StartPeriod: getDuration(env, "POSTGRES_HEALTHCHECK_START_PERIOD", "30s"),
+		},
+		Networks: []string{VaultNetworkName},
+	}
+	// The entrypoint fixes data directory ownership, then switches to the postgres user
+	hardenService(env, &service, "CHOWN", "DAC_OVERRIDE", "FOWNER", "SETGID", "SETUID")
+	return service, nil
+}
+
+func buildRedisService(env map[string]string) ServiceDefinition {
+	service := ServiceDefinition{
+		Image:         "redis:7-alpine",
+		ContainerName: scopedName(env, RedisContainerName),
+		Restart:       "on-failure",
+		Expose:        []string{strconv.Itoa(RedisDefaultPort)},
+		Volumes: []string{
+			fmt.Sprintf("%s:/data", RedisDataVolumeName),
+		},
+		HealthCheck: &HealthCheckDefinition{
+			Test:        []string{"CMD", "redis-cli", "ping"},
+			Interval:    "2s",
+			Timeout:     "3s",
 			Retries:     10,
-			StartPeriod: "30s",
+			StartPeriod: "5s",
 		},
 		Networks: []string{VaultNetworkName},
-	}, nil
+	}
+	// The entrypoint fixes /data ownership, then switches to the redis user
+	hardenService(env, &service, "CHOWN", "SETGID", "SETUID")
+	return service
+}
+
+func getRedisURL(env map[string]string) string {
+	return getEnv(env, "REDIS_URL", fmt.Sprintf("redis://%s:%d/0", RedisContainerName, RedisDefaultPort))
 }
 
 func getDatabaseURI(env map[string]string) string {
@@ -143,15 +499,21 @@ func buildVaultServices(env map[string]string, containers []string, envFilePath
 	if tmpfsSize < 1 {
 		tmpfsSize = 1024
 	}
+	startPeriod := getDuration(env, "VAULT_HEALTHCHECK_START_PERIOD", "3s")
+	retries := getPositiveInt(env, "VAULT_HEALTHCHECK_RETRIES", 1)
+	memLimit := getMemLimit(env, "VAULT_MEM_LIMIT")
+	cpuLimit := getCPULimit(env, "VAULT_CPU_LIMIT")
+	redisEnabled := isRedisCacheEnabled(env)
+	condition := vaultDependsOnCondition(env)
 
 	for _, name := range containers {
-		services[name] = ServiceDefinition{
+		service := ServiceDefinition{
 			Build: &BuildDefinition{
 				Context:    ".",
 				Dockerfile: "./infra/vault/Dockerfile",
 			},
 			Image:         "leyzen/vault:latest",
-			ContainerName: name,
+			ContainerName: scopedName(env, name),
 			EnvFile:       []string{envFilePath},
 			Restart:       "on-failure",
 			HealthCheck: &HealthCheckDefinition{
@@ -161,8 +523,8 @@ func buildVaultServices(env map[string]string, containers []string, envFilePath
 				},
 				Interval:    "1s",
 				Timeout:     "2s",
-				Retries:     1,
-				StartPeriod: "3s",
+				Retries:     retries,
+				StartPeriod: startPeriod,
 			},
 			Tmpfs: []string{
 				fmt.Sprintf("/data:size=%dM,noexec,nosuid,nodev", tmpfsSize),
@@ -172,12 +534,27 @@ func buildVaultServices(env map[string]string, containers []string, envFilePath
 				"./src/common:/common:ro",
 			},
 			DependsOn: map[string]DependsOnCondition{
-				HAProxyContainerName:  {Condition: "service_healthy"},
-				PostgresContainerName: {Condition: "service_healthy"},
+				HAProxyContainerName:  {Condition: condition},
+				PostgresContainerName: {Condition: condition},
 			},
 			Networks:        []string{VaultNetworkName},
 			StopGracePeriod: "2s",
+			MemLimit:        memLimit,
+			CPUs:            cpuLimit,
 		}
+		if redisEnabled {
+			service.Environment = map[string]string{"REDIS_URL": getRedisURL(env)}
+			service.DependsOn[RedisContainerName] = DependsOnCondition{Condition: condition}
+		}
+		// The entrypoint chowns the data directories, then drops to the vault user with su-exec.
+		// Everything it writes lives on /data, /data-source or /tmp, so the root filesystem can be
+		// made read-only on request.
+		hardenService(env, &service, "CHOWN", "DAC_OVERRIDE", "FOWNER", "SETGID", "SETUID")
+		if service.SecurityOpt != nil && getBool(env, "VAULT_READ_ONLY_ROOT", false) {
+			service.ReadOnly = true
+			service.Tmpfs = append(service.Tmpfs, "/tmp:noexec,nosuid,nodev")
+		}
+		services[name] = service
 	}
 	return services
 }
@@ -209,9 +586,9 @@ func buildBaseServices(
 		haproxyVols = append(haproxyVols, fmt.Sprintf("%s:/usr/local/etc/haproxy/ssl/cert.pem:ro", sslCertPath))
 	}
 
-	services[HAProxyContainerName] = ServiceDefinition{
+	haproxy := ServiceDefinition{
 		Image:         "haproxy:2.8-alpine",
-		ContainerName: HAProxyContainerName,
+		ContainerName: scopedName(env, HAProxyContainerName),
 		Restart:       "always",
 		Ports:         haproxyPorts,
 		Volumes:       haproxyVols,
@@ -224,9 +601,18 @@ func buildBaseServices(
 			StartPeriod: "5s",
 		},
 	}
+	hardenService(env, &haproxy, "NET_BIND_SERVICE")
+	services[HAProxyContainerName] = haproxy
 
 	// Orchestrator & Docker Proxy (only if enabled)
 	if orchestratorEnabled {
+		// Both address the replicas through the docker API, so they need container names
+		scoped := make([]string, len(webContainers))
+		for i, name := range webContainers {
+			scoped[i] = scopedName(env, name)
+		}
+		managedContainers := strings.Join(scoped, ",")
+
 		// Docker Proxy
 		services["docker-proxy"] = ServiceDefinition{
 			Build: &BuildDefinition{
@@ -234,7 +620,8 @@ func buildBaseServices(
 				Dockerfile: "Dockerfile",
 			},
 			Image:         "leyzen/docker-proxy:latest",
-			ContainerName: "docker-proxy",
+			ContainerName: scopedName(env, "docker-proxy"),
+			Profiles:      []string{OrchestrationProfile},
 			EnvFile:       []string{envFilePath},
 			Restart:       "unless-stopped",
 			Volumes: []string{
@@ -244,7 +631,7 @@ func buildBaseServices(
 			Environment: map[string]string{
 				"DOCKER_PROXY_TIMEOUT":   getEnv(env, "DOCKER_PROXY_TIMEOUT", "30"),
 				"DOCKER_PROXY_LOG_LEVEL": getEnv(env, "DOCKER_PROXY_LOG_LEVEL", "INFO"),
-				"ORCH_WEB_CONTAINERS":    strings.Join(webContainers, ","),
+				"ORCH_WEB_CONTAINERS":    managedContainers,
 				"PYTHONPATH":             "/srv:/srv/common",
 			},
 			Networks: []string{ControlNetworkName},
@@ -264,11 +651,12 @@ func buildBaseServices(
 				Dockerfile: "Dockerfile",
 			},
 			Image:         "leyzen/orchestrator:latest",
-			ContainerName: "orchestrator",
+			ContainerName: scopedName(env, "orchestrator"),
+			Profiles:      []string{OrchestrationProfile},
 			EnvFile:       []string{envFilePath},
 			Environment: map[string]string{
 				"ORCH_LOG_DIR":        "/app/logs",
-				"ORCH_WEB_CONTAINERS": strings.Join(webContainers, ","),
+				"ORCH_WEB_CONTAINERS": managedContainers,
 				"PYTHONPATH":          "/app:/common:/infra",
 				"VAULT_DB_URI":        getDatabaseURI(env),
 			},
@@ -0,0 +1,167 @@
+package compose
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func testEnv() map[string]string {
+	return map[string]string{
+		"POSTGRES_PASSWORD":    "postgres-password",
+		"SECRET_KEY":           "0123456789abcdef0123456789abcdef",
+		"ORCHESTRATOR_ENABLED": "true",
+		"CACHE_BACKEND":        "redis",
+		"LABEL_all_team":       "ops",
+		"LABEL_all_tier":       "prod",
+		"LABEL_postgres_tier":  "data",
+	}
+}
+
+func TestBuildComposeManifestIsDeterministic(t *testing.T) {
+	containers := []string{"vault_web1", "vault_web2", "vault_web3"}
+
+	first, _, err := BuildComposeManifest(testEnv(), containers, "", ".env", nil)
+	if err != nil {
+		t.Fatalf("BuildComposeManifest: %v", err)
+	}
+	// The user labels must reach the output for their ordering to be exercised
+	for _, label := range []string{"team: ops", "tier: prod", "tier: data"} {
+		if !bytes.Contains(first, []byte(label)) {
+			t.Fatalf("manifest is missing label %q", label)
+		}
+	}
+	// Map iteration order is randomized per range, so a few runs catch unsorted output
+	for i := 0; i < 10; i++ {
+		next, _, err := BuildComposeManifest(testEnv(), containers, "", ".env", nil)
+		if err != nil {
+			t.Fatalf("BuildComposeManifest: %v", err)
+		}
+		if !bytes.Equal(first, next) {
+			t.Fatalf("run %d produced different output:\n%s\n---\n%s", i+2, first, next)
+		}
+	}
+}
+
+func TestBuildComposeManifestScopesNamesToProject(t *testing.T) {
+	env := testEnv()
+	env["COMPOSE_PROJECT_NAME"] = "staging"
+	containers := []string{"vault_web1", "vault_web2"}
+
+	out, _, err := BuildComposeManifest(env, containers, "", ".env", nil)
+	if err != nil {
+		t.Fatalf("BuildComposeManifest: %v", err)
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(out, &manifest); err != nil {
+		t.Fatalf("unmarshal manifest: %v", err)
+	}
+
+	for name, service := range manifest.Services {
+		if want := "staging-" + name; service.ContainerName != want {
+			t.Errorf("service %s: container_name = %q, want %q", name, service.ContainerName, want)
+		}
+	}
+	for key, volume := range manifest.Volumes {
+		if !strings.HasPrefix(volume.Name, "staging-leyzen-") {
+			t.Errorf("volume %s: name = %q, want a staging-leyzen- prefix", key, volume.Name)
+		}
+	}
+	for key, network := range manifest.Networks {
+		if !strings.HasPrefix(network.Name, "staging-leyzen-") {
+			t.Errorf("network %s: name = %q, want a staging-leyzen- prefix", key, network.Name)
+		}
+	}
+	if got, want := manifest.Services["orchestrator"].Environment["ORCH_WEB_CONTAINERS"], "staging-vault_web1,staging-vault_web2"; got != want {
+		t.Errorf("ORCH_WEB_CONTAINERS = %q, want %q", got, want)
+	}
+}
+
+func TestBuildComposeManifestKeepsSecretsOut(t *testing.T) {
+	env := testEnv()
+	secrets := map[string]string{"POSTGRES_PASSWORD": env["POSTGRES_PASSWORD"]}
+
+	out, secretsEnv, err := BuildComposeManifest(env, []string{"vault_web1", "vault_web2"}, "", ".env", secrets)
+	if err != nil {
+		t.Fatalf("BuildComposeManifest: %v", err)
+	}
+	if bytes.Contains(out, []byte(secrets["POSTGRES_PASSWORD"])) {
+		t.Fatalf("manifest contains the decrypted secret:\n%s", out)
+	}
+	if secretsEnv["POSTGRES_PASSWORD"] != secrets["POSTGRES_PASSWORD"] {
+		t.Errorf("secrets env POSTGRES_PASSWORD = %q, want the decrypted value", secretsEnv["POSTGRES_PASSWORD"])
+	}
+	if !strings.Contains(secretsEnv["VAULT_DB_URI"], secrets["POSTGRES_PASSWORD"]) {
+		t.Errorf("secrets env VAULT_DB_URI = %q, want the database URI moved out of the manifest", secretsEnv["VAULT_DB_URI"])
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(out, &manifest); err != nil {
+		t.Fatalf("unmarshal manifest: %v", err)
+	}
+	for _, name := range []string{PostgresContainerName, "vault_web1", "orchestrator"} {
+		envFiles := manifest.Services[name].EnvFile
+		if len(envFiles) == 0 || envFiles[len(envFiles)-1] != SecretsEnvFile {
+			t.Errorf("service %s: env_file = %v, want %s last", name, envFiles, SecretsEnvFile)
+		}
+	}
+
+	// Without encrypted secrets nothing changes and there is no file to write
+	_, secretsEnv, err = BuildComposeManifest(env, []string{"vault_web1", "vault_web2"}, "", ".env", nil)
+	if err != nil {
+		t.Fatalf("BuildComposeManifest: %v", err)
+	}
+	if secretsEnv != nil {
+		t.Errorf("secrets env = %v without encrypted secrets, want nil", secretsEnv)
+	}
+}
+
+func TestOptionalVolumeNamesCoverConditionalVolumes(t *testing.T) {
+	// Every volume that comes and goes with a toggle must be listed as optional
+	env := testEnv()
+	enabled, _, err := BuildComposeManifest(env, []string{"vault_web1", "vault_web2"}, "", ".env", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env["CACHE_BACKEND"] = "memory"
+	disabled, _, err := BuildComposeManifest(env, []string{"vault_web1", "vault_web2"}, "", ".env", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var with, without Manifest
+	if err := yaml.Unmarshal(enabled, &with); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(disabled, &without); err != nil {
+		t.Fatal(err)
+	}
+	optional := make(map[string]bool)
+	for _, name := range OptionalVolumeNames {
+		optional[name] = true
+	}
+	for key, volume := range with.Volumes {
+		if _, kept := without.Volumes[key]; !kept && !optional[volume.Name] {
+			t.Errorf("volume %s disappears with Redis disabled but is not in OptionalVolumeNames", volume.Name)
+		}
+	}
+}
+
+func TestConfigWarningsReportsInvalidDependsOnCondition(t *testing.T) {
+	for _, value := range []string{"service_healthy", "Service_Started", ""} {
+		if warnings := ConfigWarnings(map[string]string{"VAULT_DEPENDS_ON_CONDITION": value}); len(warnings) != 0 {
+			t.Errorf("VAULT_DEPENDS_ON_CONDITION=%q: ConfigWarnings() = %v, want none", value, warnings)
+		}
+	}
+
+	env := map[string]string{"VAULT_DEPENDS_ON_CONDITION": "service_completed_successfully"}
+	warnings := ConfigWarnings(env)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "VAULT_DEPENDS_ON_CONDITION") {
+		t.Errorf("ConfigWarnings() = %v, want one VAULT_DEPENDS_ON_CONDITION warning", warnings)
+	}
+	if got := vaultDependsOnCondition(env); got != "service_healthy" {
+		t.Errorf("vaultDependsOnCondition() = %q, want the service_healthy fallback", got)
+	}
+}
@@ -4,6 +4,7 @@ package compose
 const (
 	PostgresContainerName = "postgres"
 	HAProxyContainerName  = "haproxy"
+	RedisContainerName    = "redis"
 )
 
 
@@ -16,6 +17,7 @@ const (
 const (
 	PostgresDataVolumeName = "postgres-data"
 	VaultDataSourceVolume  = "vault-data-source"
+	RedisDataVolumeName    = "redis-data"
 )
 
 
@@ -23,4 +25,20 @@ const (
 	VaultWebPort        = 80
 	VaultMinReplicas    = 2
 	PostgresDefaultPort = 5432
+	RedisDefaultPort    = 6379
+)
+
+
+// Compose profiles let optional parts of the stack be toggled at run time
+const (
+	OrchestrationProfile = "orchestration"
+)
+
+
+// Labels attached to every generated service for external discovery
+const (
+	LabelRole        = "com.leyzen.role"
+	LabelManaged     = "com.leyzen.managed"
+	UserLabelPrefix  = "LABEL_"
+	UserLabelAllName = "all"
 )
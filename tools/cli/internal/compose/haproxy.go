@@ -2,9 +2,99 @@ package compose
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
+// haproxyBalanceAlgorithms lists the HAPROXY_BALANCE values that make sense for the
+// vault backend.
+var haproxyBalanceAlgorithms = []string{"roundrobin", "static-rr", "leastconn", "first", "source", "random"}
+
+// HAProxyBackend tunes how vault_backend spreads traffic over the web replicas. The zero
+// value renders the previous configuration: roundrobin, equal weights, no server maxconn.
+type HAProxyBackend struct {
+	Balance string         // Balance algorithm, roundrobin when empty
+	Weights map[string]int // Weight per replica name; unlisted replicas keep HAProxy's default of 1
+	MaxConn int            // Per-server connection limit, none when 0
+}
+
+// HAProxyBackendFromEnv reads HAPROXY_BALANCE, HAPROXY_SERVER_WEIGHTS and
+// HAPROXY_SERVER_MAXCONN. Invalid values fall back to the defaults.
+func HAProxyBackendFromEnv(env map[string]string) HAProxyBackend {
+	backend := HAProxyBackend{MaxConn: getPositiveInt(env, "HAPROXY_SERVER_MAXCONN", 0)}
+
+	if balance := strings.ToLower(getEnv(env, "HAPROXY_BALANCE", "")); isBalanceAlgorithm(balance) {
+		backend.Balance = balance
+	}
+
+	for _, pair := range strings.Split(getEnv(env, "HAPROXY_SERVER_WEIGHTS", ""), ",") {
+		name, weight, ok := parseServerWeight(pair)
+		if !ok {
+			continue
+		}
+		if backend.Weights == nil {
+			backend.Weights = make(map[string]int)
+		}
+		backend.Weights[name] = weight
+	}
+
+	return backend
+}
+
+// haproxyWarnings lists the HAProxy settings HAProxyBackendFromEnv ignores as invalid.
+func haproxyWarnings(env map[string]string) []string {
+	var warnings []string
+	if raw := getEnv(env, "HAPROXY_BALANCE", ""); raw != "" && !isBalanceAlgorithm(strings.ToLower(raw)) {
+		warnings = append(warnings, fmt.Sprintf("HAPROXY_BALANCE=%q is not one of %s; roundrobin applied",
+			raw, strings.Join(haproxyBalanceAlgorithms, ", ")))
+	}
+	for _, pair := range strings.Split(getEnv(env, "HAPROXY_SERVER_WEIGHTS", ""), ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		if _, _, ok := parseServerWeight(pair); !ok {
+			warnings = append(warnings, fmt.Sprintf("HAPROXY_SERVER_WEIGHTS entry %q is not name=weight with a weight from 0 to 256; ignored", pair))
+		}
+	}
+	if raw := getEnv(env, "HAPROXY_SERVER_MAXCONN", ""); raw != "" && getPositiveInt(env, "HAPROXY_SERVER_MAXCONN", 0) == 0 {
+		warnings = append(warnings, fmt.Sprintf("HAPROXY_SERVER_MAXCONN=%q is not a positive number; no server maxconn applied", raw))
+	}
+	return warnings
+}
+
+func isBalanceAlgorithm(balance string) bool {
+	for _, algorithm := range haproxyBalanceAlgorithms {
+		if balance == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// parseServerWeight parses one name=weight entry of HAPROXY_SERVER_WEIGHTS. HAProxy
+// accepts weights from 0 to 256.
+func parseServerWeight(pair string) (string, int, bool) {
+	name, value, ok := strings.Cut(pair, "=")
+	name = strings.TrimSpace(name)
+	weight, err := strconv.Atoi(strings.TrimSpace(value))
+	if !ok || name == "" || err != nil || weight < 0 || weight > 256 {
+		return "", 0, false
+	}
+	return name, weight, true
+}
+
+// serverOptions returns the extra options of the server line for name.
+func (b HAProxyBackend) serverOptions(name string) string {
+	var opts string
+	if weight, ok := b.Weights[name]; ok {
+		opts += fmt.Sprintf(" weight %d", weight)
+	}
+	if b.MaxConn > 0 {
+		opts += fmt.Sprintf(" maxconn %d", b.MaxConn)
+	}
+	return opts
+}
+
 // RenderHAProxyConfig generates the HAProxy configuration string
 func RenderHAProxyConfig(
 	containers []string,
@@ -12,6 +102,7 @@ func RenderHAProxyConfig(
 	enableHTTPS bool,
 	sslCertPath string,
 	orchestratorEnabled bool,
+	backend HAProxyBackend,
 ) string {
 	var sb strings.Builder
 
@@ -87,7 +178,11 @@ func RenderHAProxyConfig(
 	}
 
 	sb.WriteString("backend vault_backend\n")
-	sb.WriteString("    balance roundrobin\n")
+	balance := backend.Balance
+	if balance == "" {
+		balance = "roundrobin"
+	}
+	sb.WriteString(fmt.Sprintf("    balance %s\n", balance))
 	sb.WriteString("    option http-server-close\n")
 	sb.WriteString("    option forwardfor header X-Forwarded-For if-none\n")
 	sb.WriteString("    option redispatch\n")
@@ -98,7 +193,7 @@ func RenderHAProxyConfig(
 	sb.WriteString("    http-check expect status 200\n")
 
 	for _, name := range containers {
-		sb.WriteString(fmt.Sprintf("    server %s %s:%d check\n", name, name, port))
+		sb.WriteString(fmt.Sprintf("    server %s %s:%d check%s\n", name, name, port, backend.serverOptions(name)))
 	}
 	if len(containers) == 0 {
 		sb.WriteString("    # No backend servers configured\n")
@@ -0,0 +1,42 @@
+package compose
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHAProxyBackendFromEnvWarnsOnIgnoredSettings(t *testing.T) {
+	env := map[string]string{
+		"HAPROXY_BALANCE":        "fastest",
+		"HAPROXY_SERVER_WEIGHTS": "vault_web1=10, vault_web2=heavy,vault_web3=300,=5,",
+		"HAPROXY_SERVER_MAXCONN": "-1",
+	}
+
+	backend := HAProxyBackendFromEnv(env)
+	want := HAProxyBackend{Weights: map[string]int{"vault_web1": 10}}
+	if !reflect.DeepEqual(backend, want) {
+		t.Errorf("HAProxyBackendFromEnv() = %+v, want %+v", backend, want)
+	}
+
+	warnings := strings.Join(ConfigWarnings(env), "\n")
+	for _, fragment := range []string{
+		`HAPROXY_BALANCE="fastest"`,
+		`"vault_web2=heavy"`,
+		`"vault_web3=300"`,
+		`"=5"`,
+		`HAPROXY_SERVER_MAXCONN="-1"`,
+	} {
+		if !strings.Contains(warnings, fragment) {
+			t.Errorf("ConfigWarnings() does not mention %s:\n%s", fragment, warnings)
+		}
+	}
+	if strings.Contains(warnings, "vault_web1") {
+		t.Errorf("ConfigWarnings() flags the valid weight:\n%s", warnings)
+	}
+
+	valid := map[string]string{"HAPROXY_BALANCE": "LeastConn", "HAPROXY_SERVER_WEIGHTS": "vault_web1=0", "HAPROXY_SERVER_MAXCONN": "50"}
+	if warnings := ConfigWarnings(valid); len(warnings) != 0 {
+		t.Errorf("ConfigWarnings() = %v for valid settings, want none", warnings)
+	}
+}
@@ -8,12 +8,15 @@ import (
 )
 
 // PrepareSSLCertificateBundle ensures HAProxy has access to a PEM file that includes the cert and key.
+// With dryRun the inputs are still checked, but the bundle path is returned without writing it.
 func PrepareSSLCertificateBundle(
 	enableHTTPS bool,
 	certPath string,
 	keyPath string,
+	chainPath string,
 	rootDir string,
 	outputPath string,
+	dryRun bool,
 ) (string, []string, error) {
 	if !enableHTTPS || certPath == "" {
 		return "", nil, nil
@@ -29,6 +32,9 @@ func PrepareSSLCertificateBundle(
 	}
 
 	writePEM := func(target string, content string) error {
+		if dryRun {
+			return nil
+		}
 		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
 			return fmt.Errorf("could not create directory for PEM bundle: %w", err)
 		}
@@ -50,6 +56,24 @@ func PrepareSSLCertificateBundle(
 	}
 	certText := string(certContent)
 
+	chainText := ""
+	if chainPath != "" {
+		chainContent, err := os.ReadFile(resolvePath(chainPath, rootDir))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("SSL chain file is not readable: %v", err))
+			return "", warnings, nil
+		}
+		chainText = string(chainContent)
+		if !strings.Contains(certText, "CERTIFICATE") {
+			warnings = append(warnings, "SSL certificate file contains no CERTIFICATE block")
+			return "", warnings, nil
+		}
+		if !strings.Contains(chainText, "CERTIFICATE") {
+			warnings = append(warnings, "SSL chain file contains no CERTIFICATE block")
+			return "", warnings, nil
+		}
+	}
+
 	if keyPath != "" {
 		keyFile := resolvePath(keyPath, rootDir)
 		keyContent, err := os.ReadFile(keyFile)
@@ -59,8 +83,7 @@ func PrepareSSLCertificateBundle(
 		}
 		keyText := string(keyContent)
 
-		combined := strings.TrimRight(certText, "\n") + "\n" + strings.TrimSpace(keyText) + "\n"
-		if err := writePEM(pemTarget, combined); err != nil {
+		if err := writePEM(pemTarget, assembleBundle(certText, chainText, keyText)); err != nil {
 			warnings = append(warnings, err.Error())
 			return "", warnings, nil
 		}
@@ -72,7 +95,11 @@ func PrepareSSLCertificateBundle(
 		return "", warnings, nil
 	}
 
-	if certFile == pemTarget {
+	if chainText != "" {
+		// The chain goes between the leaf and the key embedded in the certificate file
+		leafText, keyText := splitPrivateKey(certText)
+		certText = assembleBundle(leafText, chainText, keyText)
+	} else if certFile == pemTarget {
 		return certFile, warnings, nil
 	}
 
@@ -84,9 +111,86 @@ func PrepareSSLCertificateBundle(
 	return pemTarget, warnings, nil
 }
 
+// assembleBundle orders the PEM bundle as HAProxy expects: leaf certificate, then the
+// intermediate chain, then the private key.
+func assembleBundle(certText, chainText, keyText string) string {
+	parts := []string{strings.TrimSpace(certText)}
+	if strings.TrimSpace(chainText) != "" {
+		parts = append(parts, strings.TrimSpace(chainText))
+	}
+	parts = append(parts, strings.TrimSpace(keyText))
+	return strings.Join(parts, "\n") + "\n"
+}
+
+// splitPrivateKey separates the PRIVATE KEY block from the rest of a combined PEM file.
+func splitPrivateKey(text string) (string, string) {
+	start := strings.Index(text, "-----BEGIN ")
+	for start != -1 {
+		lineEnd := strings.Index(text[start:], "\n")
+		if lineEnd == -1 {
+			break
+		}
+		if strings.Contains(text[start:start+lineEnd], "PRIVATE KEY") {
+			endMarker := strings.Index(text[start:], "-----END ")
+			if endMarker == -1 {
+				break
+			}
+			stop := start + endMarker
+			if nl := strings.Index(text[stop:], "\n"); nl != -1 {
+				stop += nl + 1
+			} else {
+				stop = len(text)
+			}
+			return text[:start] + text[stop:], text[start:stop]
+		}
+		next := strings.Index(text[start+lineEnd:], "-----BEGIN ")
+		if next == -1 {
+			break
+		}
+		start += lineEnd + next
+	}
+	return text, ""
+}
+
 func resolvePath(pathStr string, rootDir string) string {
 	if filepath.IsAbs(pathStr) {
 		return pathStr
 	}
 	return filepath.Join(rootDir, pathStr)
 }
+
+// ValidateSSLFiles checks the files HTTPS depends on and returns one message per problem.
+// PrepareSSLCertificateBundle only warns and silently disables HTTPS, so callers can use
+// this to fail before the stack starts.
+func ValidateSSLFiles(certPath string, keyPath string, chainPath string, rootDir string) []string {
+	if strings.TrimSpace(certPath) == "" {
+		return []string{"ENABLE_HTTPS is true but SSL_CERT_PATH is not set"}
+	}
+
+	var problems []string
+	certContent, err := os.ReadFile(resolvePath(certPath, rootDir))
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("SSL_CERT_PATH is not a readable file: %v", err))
+	} else if !strings.Contains(string(certContent), "CERTIFICATE") {
+		problems = append(problems, "SSL_CERT_PATH contains no CERTIFICATE block")
+	}
+
+	if chainPath != "" {
+		chainContent, err := os.ReadFile(resolvePath(chainPath, rootDir))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("SSL_CHAIN_PATH is not a readable file: %v", err))
+		} else if !strings.Contains(string(chainContent), "CERTIFICATE") {
+			problems = append(problems, "SSL_CHAIN_PATH contains no CERTIFICATE block")
+		}
+	}
+
+	if keyPath != "" {
+		if _, err := os.ReadFile(resolvePath(keyPath, rootDir)); err != nil {
+			problems = append(problems, fmt.Sprintf("SSL_KEY_PATH is not a readable file: %v", err))
+		}
+	} else if err == nil && !strings.Contains(string(certContent), "PRIVATE KEY") {
+		problems = append(problems, "SSL_CERT_PATH has no PRIVATE KEY block and SSL_KEY_PATH is not set")
+	}
+
+	return problems
+}
@@ -18,6 +18,15 @@ type ServiceDefinition struct {
 	StopGracePeriod string                        `yaml:"stop_grace_period,omitempty"`
 	Command         interface{}                   `yaml:"command,omitempty"`
 	User            string                        `yaml:"user,omitempty"`
+	MemLimit        string                        `yaml:"mem_limit,omitempty"`
+	CPUs            float64                       `yaml:"cpus,omitempty"`
+	Logging         *LoggingDefinition            `yaml:"logging,omitempty"`
+	CapDrop         []string                      `yaml:"cap_drop,omitempty"`
+	CapAdd          []string                      `yaml:"cap_add,omitempty"`
+	ReadOnly        bool                          `yaml:"read_only,omitempty"`
+	SecurityOpt     []string                      `yaml:"security_opt,omitempty"`
+	Profiles        []string                      `yaml:"profiles,omitempty"`
+	Labels          map[string]string             `yaml:"labels,omitempty"`
 }
 
 
@@ -32,6 +41,12 @@ type DependsOnCondition struct {
 }
 
 
+type LoggingDefinition struct {
+	Driver  string            `yaml:"driver,omitempty"`
+	Options map[string]string `yaml:"options,omitempty"`
+}
+
+
 type HealthCheckDefinition struct {
 	Test        []string `yaml:"test,omitempty"`
 	Interval    string   `yaml:"interval,omitempty"`
@@ -53,6 +68,9 @@ type NetworkDefinition struct {
 }
 
 
+// Manifest is the docker-generated.yml document. yaml.v3 emits map keys in sorted
+// order, so services, volumes, networks and every map field marshal deterministically;
+// slice fields must be filled in a stable order by the builders.
 type Manifest struct {
 	Services map[string]ServiceDefinition `yaml:"services"`
 	Volumes  map[string]VolumeDefinition  `yaml:"volumes,omitempty"`
@@ -3,16 +3,279 @@ package internal
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"leyzenctl/internal/compose"
+)
+
+// DefaultCommandTimeout bounds docker invocations unless --timeout overrides it.
+const DefaultCommandTimeout = 10 * time.Minute
+
+var (
+	commandTimeout    = DefaultCommandTimeout
+	commandTimeoutSet bool
+)
+
+// SetCommandTimeout changes the timeout applied to docker invocations, including the
+// backups and internal API calls that otherwise keep their own longer or shorter limits.
+func SetCommandTimeout(timeout time.Duration) {
+	commandTimeout = timeout
+	commandTimeoutSet = true
+}
+
+// timeoutOr returns the --timeout value when one was given, and fallback otherwise.
+func timeoutOr(fallback time.Duration) time.Duration {
+	if commandTimeoutSet {
+		return commandTimeout
+	}
+	return fallback
+}
+
+var dryRun bool
+
+// SetDryRun makes compose invocations and configuration writes print what they would
+// do instead of doing it (--dry-run).
+func SetDryRun(enabled bool) {
+	dryRun = enabled
+}
+
+// DryRun reports whether --dry-run is on.
+func DryRun() bool {
+	return dryRun
+}
+
+// commandLine formats a command for display, quoting arguments the shell would split.
+func commandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n'\"$`\\*?;&|<>()") {
+			arg = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+// composeProfiles holds the profiles selected with --profile. When empty, every
+// profile declared in docker-generated.yml is enabled so the whole stack is managed.
+var composeProfiles []string
+
+// SetComposeProfiles selects the compose profiles passed to lifecycle commands.
+func SetComposeProfiles(profiles []string) {
+	composeProfiles = profiles
+}
+
+// composeProjectName is passed to compose with -p so several stacks can share a host.
+// When empty, compose derives the project name from the repository directory.
+var composeProjectName string
+
+// SetComposeProjectName selects the compose project name used by every invocation.
+func SetComposeProjectName(name string) {
+	composeProjectName = strings.TrimSpace(name)
+}
+
+// ResolveComposeProjectName returns the project name to use: the --project-name flag,
+// then COMPOSE_PROJECT_NAME from the environment, then from the env file.
+func ResolveComposeProjectName(envFile, flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if name := os.Getenv("COMPOSE_PROJECT_NAME"); name != "" {
+		return name
+	}
+	resolvedEnv, err := ResolveEnvFilePath(envFile)
+	if err != nil {
+		return ""
+	}
+	env, err := LoadEnvFile(resolvedEnv)
+	if err != nil {
+		return ""
+	}
+	name, _ := env.Get("COMPOSE_PROJECT_NAME")
+	return strings.TrimSpace(name)
+}
+
+// ScopedName returns a container, volume or network name as the generated manifest
+// declares it for the current project: prefixed with "<project>-" when a project name
+// is set, unchanged otherwise.
+func ScopedName(name string) string {
+	return compose.ScopedName(composeProjectName, name)
+}
+
+// ServiceFromContainerName strips the "<project>-" prefix of the current project and the
+// "-<index>" suffix compose adds to containers without an explicit container_name.
+// Hyphens inside the service name, as in docker-proxy, are kept.
+func ServiceFromContainerName(name string) string {
+	if idx := strings.LastIndex(name, "-"); idx != -1 && isDigits(name[idx+1:]) {
+		name = name[:idx]
+	}
+	if composeProjectName != "" {
+		name = strings.TrimPrefix(name, composeProjectName+"-")
+	}
+	return name
+}
+
+// legacyPSPlaceholders maps the compose ps format fields to their docker ps equivalents.
+var legacyPSPlaceholders = strings.NewReplacer(
+	"{{.Name}}", "{{.Names}}",
+	"{{.Service}}", `{{.Label "com.docker.compose.service"}}`,
+)
+
+// legacyPSArgs builds the `docker ps` command line listing the containers of the compose
+// project through the labels docker-compose v1 sets on them. Format fields are translated
+// and --filter is passed through, so callers use the same arguments for both versions.
+func legacyPSArgs(repoRoot string, args []string) []string {
+	project := composeProjectName
+	if project == "" {
+		project = defaultProjectName(repoRoot)
+	}
+	fullArgs := []string{"docker", "ps", "-a", "--filter", "label=com.docker.compose.project=" + project}
+	for _, arg := range args {
+		if arg == "-a" {
+			continue
+		}
+		fullArgs = append(fullArgs, legacyPSPlaceholders.Replace(arg))
+	}
+	return fullArgs
+}
+
+// defaultProjectName returns the project name docker-compose v1 derives from the project
+// directory: its base name, lowercased, without characters other than [a-z0-9_-].
+func defaultProjectName(dir string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			return r
+		}
+		return -1
+	}, strings.ToLower(filepath.Base(dir)))
+}
+
+var (
+	composeCommandOnce sync.Once
+	composeCommand     []string
+	composeCommandErr  error
+)
+
+// detectComposeCommand returns the compose entrypoint: `docker compose` (v2) when the
+// plugin answers, otherwise the legacy docker-compose binary. The result is cached.
+func detectComposeCommand() ([]string, error) {
+	composeCommandOnce.Do(func() {
+		if _, err := exec.LookPath("docker"); err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if exec.CommandContext(ctx, "docker", "compose", "version").Run() == nil {
+				composeCommand = []string{"docker", "compose"}
+				return
+			}
+		}
+		if _, err := exec.LookPath("docker-compose"); err == nil {
+			composeCommand = []string{"docker-compose"}
+			return
+		}
+		composeCommandErr = errors.New("docker compose is required but neither `docker compose` (v2 plugin) nor `docker-compose` (v1) is available")
+	})
+	return composeCommand, composeCommandErr
+}
+
+// composeArgs returns the compose command line up to the subcommand, starting with the
+// binary to execute. With allProfiles set, every declared profile is enabled regardless
+// of --profile, so listings cover the full stack.
+func composeArgs(allProfiles bool) ([]string, error) {
+	base, err := detectComposeCommand()
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]string{}, base...), composeOptions(allProfiles)...), nil
+}
+
+// composeOptions returns the global compose options: project name, compose file and profiles.
+func composeOptions(allProfiles bool) []string {
+	var args []string
+	if composeProjectName != "" {
+		args = append(args, "-p", composeProjectName)
+	}
+	args = append(args, "-f", "docker-generated.yml")
+	profiles := composeProfiles
+	if allProfiles || len(profiles) == 0 {
+		profiles = declaredProfiles()
+	}
+	for _, profile := range profiles {
+		args = append(args, "--profile", profile)
+	}
+	return args
+}
+
+// declaredProfiles lists the profiles used by services in docker-generated.yml.
+func declaredProfiles() []string {
+	manifest, err := LoadGeneratedManifest()
+	if err != nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var profiles []string
+	for _, svc := range manifest.Services {
+		for _, profile := range svc.Profiles {
+			if !seen[profile] {
+				seen[profile] = true
+				profiles = append(profiles, profile)
+			}
+		}
+	}
+	sort.Strings(profiles)
+	return profiles
+}
+
+// SetDockerContext makes every docker invocation of this process target the named
+// context by exporting DOCKER_CONTEXT. Child processes inherit the environment.
+func SetDockerContext(name string) error {
+	return os.Setenv("DOCKER_CONTEXT", name)
+}
+
+var (
+	dockerIsRemoteOnce sync.Once
+	dockerIsRemote     bool
 )
 
-const commandTimeout = 10 * time.Minute
+// DockerIsRemote reports whether DOCKER_HOST or DOCKER_CONTEXT points the docker CLI
+// at a daemon on another machine. Host statistics read locally do not describe it.
+// The result is cached: the context is fixed once the root command has started.
+func DockerIsRemote() bool {
+	dockerIsRemoteOnce.Do(func() {
+		dockerIsRemote = detectRemoteDocker()
+	})
+	return dockerIsRemote
+}
+
+func detectRemoteDocker() bool {
+	host := os.Getenv("DOCKER_HOST")
+	if host == "" {
+		name := os.Getenv("DOCKER_CONTEXT")
+		if name == "" || name == "default" {
+			return false
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		out, err := exec.CommandContext(ctx, "docker", "context", "inspect", "--format", "{{.Endpoints.docker.Host}}", name).Output()
+		if err != nil {
+			return false
+		}
+		host = strings.TrimSpace(string(out))
+	}
+	return !strings.HasPrefix(host, "unix://") && !strings.HasPrefix(host, "npipe://")
+}
 
 // RunCompose executes `docker compose` with the provided arguments and streams the output.
 func RunCompose(envFile string, args ...string) error {
@@ -31,13 +294,25 @@ func RunComposeWithWriter(stdout, stderr io.Writer, envFile string, args ...stri
 		return fmt.Errorf("failed to find repository root: %w", err)
 	}
 
-	fullArgs := []string{"compose", "-f", "docker-generated.yml"}
+	fullArgs, err := composeArgs(false)
+	if err != nil {
+		if !dryRun {
+			return err
+		}
+		// A plan does not need docker installed; assume the v2 plugin
+		fullArgs = append([]string{"docker", "compose"}, composeOptions(false)...)
+	}
 	fullArgs = append(fullArgs, args...)
 
+	if dryRun {
+		fmt.Fprintf(stdout, "[dry-run] (cd %s && LEYZEN_ENV_FILE=%s %s)\n", commandLine([]string{repoRoot}), commandLine([]string{resolvedEnv}), commandLine(fullArgs))
+		return nil
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "docker", fullArgs...)
+	cmd := exec.CommandContext(ctx, fullArgs[0], fullArgs[1:]...)
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
 	cmd.Dir = repoRoot // Set working directory to repo root
@@ -50,19 +325,22 @@ func RunComposeWithWriter(stdout, stderr io.Writer, envFile string, args ...stri
 	}
 
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("docker %s: %w", strings.Join(fullArgs, " "), err)
+		return fmt.Errorf("%s: %w", strings.Join(fullArgs, " "), err)
 	}
 	return nil
 }
 
 // DockerComposePS executes `docker compose ps` with the provided arguments and returns its output.
+// With docker-compose v1, whose ps supports neither --format nor --filter, the project's
+// containers are listed with `docker ps` instead (see legacyPSArgs).
 func DockerComposePS(envFile string, args ...string) (string, error) {
 	resolvedEnv, err := ResolveEnvFilePath(envFile)
 	if err != nil {
 		return "", err
 	}
 
-	if err := ensureBinaryAvailable("docker"); err != nil {
+	fullArgs, err := composeArgs(true)
+	if err != nil {
 		return "", err
 	}
 
@@ -74,10 +352,14 @@ func DockerComposePS(envFile string, args ...string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
 	defer cancel()
 
-	fullArgs := []string{"compose", "-f", "docker-generated.yml", "ps", "-a"}
-	fullArgs = append(fullArgs, args...)
+	if fullArgs[0] == "docker-compose" {
+		fullArgs = legacyPSArgs(repoRoot, args)
+	} else {
+		fullArgs = append(fullArgs, "ps", "-a")
+		fullArgs = append(fullArgs, args...)
+	}
 
-	cmd := exec.CommandContext(ctx, "docker", fullArgs...)
+	cmd := exec.CommandContext(ctx, fullArgs[0], fullArgs[1:]...)
 	cmd.Dir = repoRoot // Set working directory to repo root
 
 	// Set LEYZEN_ENV_FILE environment variable if env file is specified
@@ -100,9 +382,9 @@ func DockerComposePS(envFile string, args ...string) (string, error) {
 
 // ProjectStatus represents the status of a service in the project.
 type ProjectStatus struct {
-	Name   string
-	Status string
-	Age    string
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Age    string `json:"age"`
 }
 
 // GetProjectStatuses retrieves the status of all services defined in the compose file.
@@ -164,6 +446,213 @@ func GetProjectStatuses(envFile string) ([]ProjectStatus, error) {
 	return results, nil
 }
 
+// RestartInfo is the restart data docker keeps for a container, along with the progress
+// of its healthcheck: consecutive failures out of the retries allowed before unhealthy.
+// FailingStreak stays at 0 during the start period, so HealthLogFailures also counts the
+// failed probes at the end of the health log, which docker records either way.
+type RestartInfo struct {
+	RestartCount      int
+	ExitCode          int
+	HealthFailures    int
+	HealthRetries     int
+	HealthLogFailures int
+}
+
+// dockerDefaultHealthRetries applies when a healthcheck does not set retries.
+const dockerDefaultHealthRetries = 3
+
+// GetRestartInfo returns the restart count, last exit code and healthcheck progress of
+// every container, keyed by service name.
+func GetRestartInfo(envFile string) (map[string]RestartInfo, error) {
+	psOutput, err := DockerComposePS(envFile, "--format", "{{.Service}}\t{{.Name}}")
+	if err != nil {
+		return nil, err
+	}
+
+	services := make(map[string]string)
+	var names []string
+	for _, line := range strings.Split(psOutput, "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) >= 2 {
+			services[parts[1]] = parts[0]
+			names = append(names, parts[1])
+		}
+	}
+	if len(names) == 0 {
+		return map[string]RestartInfo{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	format := "{{.Name}}\t{{.RestartCount}}\t{{.State.ExitCode}}" +
+		"\t{{if .State.Health}}{{.State.Health.FailingStreak}}{{end}}" +
+		"\t{{if .Config.Healthcheck}}{{.Config.Healthcheck.Retries}}{{end}}" +
+		"\t{{if .State.Health}}{{range .State.Health.Log}}{{.ExitCode}} {{end}}{{end}}"
+	args := append([]string{"inspect", "--format", format}, names...)
+	out, err := exec.CommandContext(ctx, "docker", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("docker inspect: %w", err)
+	}
+
+	info := make(map[string]RestartInfo)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) < 3 {
+			continue
+		}
+		service, ok := services[strings.TrimPrefix(parts[0], "/")]
+		if !ok {
+			continue
+		}
+		count, _ := strconv.Atoi(parts[1])
+		exitCode, _ := strconv.Atoi(parts[2])
+		entry := RestartInfo{RestartCount: count, ExitCode: exitCode}
+		if len(parts) >= 5 && parts[3] != "" {
+			entry.HealthFailures, _ = strconv.Atoi(parts[3])
+			entry.HealthRetries, _ = strconv.Atoi(parts[4])
+			if entry.HealthRetries == 0 {
+				entry.HealthRetries = dockerDefaultHealthRetries
+			}
+		}
+		if len(parts) >= 6 {
+			entry.HealthLogFailures = trailingProbeFailures(parts[5])
+		}
+		info[service] = entry
+	}
+	return info, nil
+}
+
+// trailingProbeFailures counts the failed probes at the end of a space-separated list of
+// healthcheck exit codes, oldest first.
+func trailingProbeFailures(exitCodes string) int {
+	codes := strings.Fields(exitCodes)
+	failures := 0
+	for i := len(codes) - 1; i >= 0 && codes[i] != "0"; i-- {
+		failures++
+	}
+	return failures
+}
+
+// ServiceLogTail returns the last lines logged by a service, without the compose prefix.
+func ServiceLogTail(envFile, service string, lines int) ([]string, error) {
+	var stdout bytes.Buffer
+	if err := RunComposeWithWriter(&stdout, io.Discard, envFile, "logs", "--no-color", "--no-log-prefix", "--tail", strconv.Itoa(lines), service); err != nil {
+		return nil, err
+	}
+	output := strings.TrimSpace(stdout.String())
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// ContainerDetail is the subset of `docker inspect` shown when drilling into a container.
+type ContainerDetail struct {
+	Name         string
+	Image        string
+	State        string
+	Health       string // Empty when the container has no healthcheck
+	StartedAt    time.Time
+	RestartCount int
+	ExitCode     int
+	LastError    string   // Runtime error or output of the last failed healthcheck
+	Ports        []string // Published ports as host:port->container/proto
+}
+
+// ContainerForService returns the container of a service, running or not.
+func ContainerForService(envFile, service string) (string, error) {
+	output, err := DockerComposePS(envFile, "--format", "{{.Service}}\t{{.Name}}")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) >= 2 && parts[0] == service {
+			return parts[1], nil
+		}
+	}
+	return "", fmt.Errorf("no container found for service %s", service)
+}
+
+// InspectContainer runs `docker inspect` on a container and extracts the fields of ContainerDetail.
+func InspectContainer(name string) (ContainerDetail, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", "inspect", name)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return ContainerDetail{}, fmt.Errorf("docker inspect %s: %w - %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var raw []struct {
+		Name         string
+		RestartCount int
+		Config       struct{ Image string }
+		State        struct {
+			Status    string
+			ExitCode  int
+			Error     string
+			StartedAt time.Time
+			Health    *struct {
+				Status string
+				Log    []struct {
+					ExitCode int
+					Output   string
+				}
+			}
+		}
+		NetworkSettings struct {
+			Ports map[string][]struct {
+				HostIP   string `json:"HostIp"`
+				HostPort string
+			}
+		}
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &raw); err != nil {
+		return ContainerDetail{}, fmt.Errorf("parse docker inspect output: %w", err)
+	}
+	if len(raw) == 0 {
+		return ContainerDetail{}, fmt.Errorf("container %s not found", name)
+	}
+	c := raw[0]
+
+	detail := ContainerDetail{
+		Name:         strings.TrimPrefix(c.Name, "/"),
+		Image:        c.Config.Image,
+		State:        c.State.Status,
+		StartedAt:    c.State.StartedAt,
+		RestartCount: c.RestartCount,
+		ExitCode:     c.State.ExitCode,
+		LastError:    strings.TrimSpace(c.State.Error),
+	}
+	if c.State.Health != nil {
+		detail.Health = c.State.Health.Status
+		if detail.LastError == "" {
+			for i := len(c.State.Health.Log) - 1; i >= 0; i-- {
+				if entry := c.State.Health.Log[i]; entry.ExitCode != 0 {
+					detail.LastError = strings.TrimSpace(entry.Output)
+					break
+				}
+			}
+		}
+	}
+	for containerPort, bindings := range c.NetworkSettings.Ports {
+		if len(bindings) == 0 {
+			detail.Ports = append(detail.Ports, containerPort)
+			continue
+		}
+		for _, b := range bindings {
+			detail.Ports = append(detail.Ports, fmt.Sprintf("%s:%s->%s", b.HostIP, b.HostPort, containerPort))
+		}
+	}
+	sort.Strings(detail.Ports)
+	return detail, nil
+}
+
 // GetComposeServices retrieves the list of services from docker-compose configuration.
 func GetComposeServices(envFile string) ([]string, error) {
 	resolvedEnv, err := ResolveEnvFilePath(envFile)
@@ -171,7 +660,8 @@ func GetComposeServices(envFile string) ([]string, error) {
 		return nil, err
 	}
 
-	if err := ensureBinaryAvailable("docker"); err != nil {
+	fullArgs, err := composeArgs(true)
+	if err != nil {
 		return nil, err
 	}
 
@@ -183,9 +673,9 @@ func GetComposeServices(envFile string) ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
 	defer cancel()
 
-	fullArgs := []string{"compose", "-f", "docker-generated.yml", "config", "--services"}
+	fullArgs = append(fullArgs, "config", "--services")
 
-	cmd := exec.CommandContext(ctx, "docker", fullArgs...)
+	cmd := exec.CommandContext(ctx, fullArgs[0], fullArgs[1:]...)
 	cmd.Dir = repoRoot // Set working directory to repo root
 
 	// Set LEYZEN_ENV_FILE environment variable if env file is specified
@@ -221,6 +711,115 @@ func GetComposeServices(envFile string) ([]string, error) {
 	return result, nil
 }
 
+// ValidateServiceNames checks requested service names against the compose services, so a
+// typo fails with the closest valid names instead of an obscure docker compose error.
+func ValidateServiceNames(envFile string, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	services, err := GetComposeServices(envFile)
+	if err != nil {
+		// A dry run still prints the commands when docker is unavailable
+		if dryRun {
+			return nil
+		}
+		return fmt.Errorf("failed to list services: %w", err)
+	}
+
+	known := make(map[string]bool, len(services))
+	for _, service := range services {
+		known[service] = true
+	}
+	var unknown []string
+	for _, name := range names {
+		if known[name] {
+			continue
+		}
+		if matches := closestMatches(name, services); len(matches) > 0 {
+			unknown = append(unknown, fmt.Sprintf("%s (did you mean %s?)", name, strings.Join(matches, ", ")))
+		} else {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	return fmt.Errorf("unknown service(s): %s\nAvailable services: %s", strings.Join(unknown, "; "), strings.Join(services, ", "))
+}
+
+// ServiceContainers returns the running containers of a service. A name without its
+// replica number (for example vault_web) matches every replica, with the active
+// replica first.
+func ServiceContainers(envFile, service string) ([]string, error) {
+	output, err := DockerComposePS(envFile, "--filter", "status=running", "--format", "{{.Service}}\t{{.Name}}")
+	if err != nil {
+		return nil, err
+	}
+
+	var exact, replicas []string
+	for _, line := range strings.Split(output, "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) < 2 {
+			continue
+		}
+		name, container := parts[0], parts[1]
+		switch {
+		case name == service:
+			exact = append(exact, container)
+		case strings.HasPrefix(name, service) && isDigits(strings.TrimPrefix(name, service)):
+			replicas = append(replicas, container)
+		}
+	}
+	if len(exact) > 0 {
+		return exact, nil
+	}
+
+	sort.Strings(replicas)
+	// Put the replica that currently serves traffic first
+	if active, err := getActiveContainer(envFile); err == nil && active != "" {
+		for i, name := range replicas {
+			if name == active {
+				replicas[0], replicas[i] = replicas[i], replicas[0]
+				break
+			}
+		}
+	}
+	return replicas, nil
+}
+
+// ExecInContainer runs a command in a container attached to the current terminal.
+// No timeout applies since the session is interactive.
+func ExecInContainer(container string, command []string) error {
+	if err := ensureBinaryAvailable("docker"); err != nil {
+		return err
+	}
+
+	args := []string{"exec", "-i"}
+	if stat, err := os.Stdin.Stat(); err == nil && stat.Mode()&os.ModeCharDevice != 0 {
+		args = append(args, "-t")
+	}
+	args = append(args, container)
+	args = append(args, command...)
+
+	cmd := exec.Command("docker", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 func runStreaming(stdout, stderr io.Writer, args []string) error {
 	if err := ensureBinaryAvailable("docker"); err != nil {
 		return err
@@ -238,3 +837,86 @@ func runStreaming(stdout, stderr io.Writer, args []string) error {
 	}
 	return nil
 }
+
+// LoadGeneratedManifest parses docker-generated.yml from the repository root.
+func LoadGeneratedManifest() (compose.Manifest, error) {
+	var manifest compose.Manifest
+	repoRoot, err := FindRepoRoot()
+	if err != nil {
+		return manifest, fmt.Errorf("failed to find repository root: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(repoRoot, "docker-generated.yml"))
+	if err != nil {
+		return manifest, fmt.Errorf("read docker-generated.yml: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("parse docker-generated.yml: %w", err)
+	}
+	return manifest, nil
+}
+
+// ComposeServiceImages returns the image reference of every service in docker-generated.yml.
+func ComposeServiceImages() (map[string]string, error) {
+	manifest, err := LoadGeneratedManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(map[string]string)
+	for name, svc := range manifest.Services {
+		if svc.Image != "" {
+			images[name] = svc.Image
+		}
+	}
+	return images, nil
+}
+
+// ImageID returns the local image ID for the given reference, or an empty string if it is not present.
+func ImageID(image string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "docker", "image", "inspect", "--format", "{{.Id}}", image).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// ListVolumes returns the names of the docker volumes starting with prefix.
+func ListVolumes(prefix string) ([]string, error) {
+	if err := ensureBinaryAvailable("docker"); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	// The name filter matches substrings, so the prefix is checked again below
+	out, err := exec.CommandContext(ctx, "docker", "volume", "ls", "--filter", "name="+prefix, "--format", "{{.Name}}").Output()
+	if err != nil {
+		return nil, fmt.Errorf("docker volume ls: %w", err)
+	}
+
+	var volumes []string
+	for _, line := range strings.Split(string(out), "\n") {
+		name := strings.TrimSpace(line)
+		if strings.HasPrefix(name, prefix) {
+			volumes = append(volumes, name)
+		}
+	}
+	sort.Strings(volumes)
+	return volumes, nil
+}
+
+// RemoveVolumes deletes the given docker volumes. Docker refuses volumes still used by a container.
+func RemoveVolumes(stdout, stderr io.Writer, names []string) error {
+	args := append([]string{"volume", "rm"}, names...)
+	if dryRun {
+		fmt.Fprintf(stdout, "[dry-run] %s\n", commandLine(append([]string{"docker"}, args...)))
+		return nil
+	}
+	return runStreaming(stdout, stderr, args)
+}
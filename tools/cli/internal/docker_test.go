@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestServiceFromContainerName(t *testing.T) {
+	tests := []struct {
+		project string
+		name    string
+		want    string
+	}{
+		{"", "vault_web1", "vault_web1"},
+		{"", "docker-proxy", "docker-proxy"},
+		{"staging", "staging-vault_web2", "vault_web2"},
+		{"staging", "staging-docker-proxy", "docker-proxy"},
+		{"staging", "staging-postgres-1", "postgres"},
+		{"my-stack", "my-stack-orchestrator", "orchestrator"},
+	}
+	t.Cleanup(func() { SetComposeProjectName("") })
+	for _, tt := range tests {
+		SetComposeProjectName(tt.project)
+		if got := ServiceFromContainerName(tt.name); got != tt.want {
+			t.Errorf("project %q: ServiceFromContainerName(%q) = %q, want %q", tt.project, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLegacyPSArgs(t *testing.T) {
+	t.Cleanup(func() { SetComposeProjectName("") })
+
+	SetComposeProjectName("")
+	got := legacyPSArgs("/srv/Leyzen Vault", []string{"-a", "--filter", "status=running", "--format", "{{.Service}}\t{{.Name}}"})
+	want := []string{
+		"docker", "ps", "-a", "--filter", "label=com.docker.compose.project=leyzenvault",
+		"--filter", "status=running", "--format", "{{.Label \"com.docker.compose.service\"}}\t{{.Names}}",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("legacyPSArgs() = %q, want %q", got, want)
+	}
+
+	SetComposeProjectName("staging")
+	got = legacyPSArgs("/srv/leyzen", []string{"--format", "{{.Name}}"})
+	want = []string{"docker", "ps", "-a", "--filter", "label=com.docker.compose.project=staging", "--format", "{{.Names}}"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("legacyPSArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestTrailingProbeFailures(t *testing.T) {
+	tests := map[string]int{
+		"":          0,
+		"0 0 ":      0,
+		"1 ":        1,
+		"0 1 -1 ":   2,
+		"1 1 0 1 ":  1,
+		"1 1 1 1 1": 5,
+	}
+	for codes, want := range tests {
+		if got := trailingProbeFailures(codes); got != want {
+			t.Errorf("trailingProbeFailures(%q) = %d, want %d", codes, got, want)
+		}
+	}
+}
+
+func TestTimeoutOrFollowsTimeoutFlag(t *testing.T) {
+	t.Cleanup(func() { commandTimeout, commandTimeoutSet = DefaultCommandTimeout, false })
+
+	if got := timeoutOr(backupTimeout); got != backupTimeout {
+		t.Errorf("timeoutOr() = %s without --timeout, want the %s default", got, backupTimeout)
+	}
+	SetCommandTimeout(2 * time.Hour)
+	if got := timeoutOr(backupTimeout); got != 2*time.Hour {
+		t.Errorf("timeoutOr() = %s with --timeout 2h, want 2h", got)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "redis", 5},
+		{"haproxy", "haproxy", 0},
+		{"kitten", "sitting", 3},
+		{"café", "cafe", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClosestMatches(t *testing.T) {
+	services := []string{"haproxy", "postgres", "redis", "vault"}
+	replicas := []string{"web1", "web2", "web3", "web4", "web5"}
+	tests := []struct {
+		name       string
+		input      string
+		candidates []string
+		want       []string
+	}{
+		{"typo within limit", "postgress", services, []string{"postgres"}},
+		{"too far off", "nginx", services, nil},
+		{"nearest wins", "vaul", append(services, "vaulted"), []string{"vault"}},
+		{"ties capped", "web", replicas, []string{"web1", "web2", "web3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := closestMatches(tt.input, tt.candidates); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("closestMatches(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,11 +2,17 @@ package internal
 
 import (
 	"bufio"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // EnvEntry represents either a key-value pair or a raw line in an env file.
@@ -15,6 +21,8 @@ type EnvEntry struct {
 	Value  string
 	Raw    string
 	IsPair bool
+	Quote  byte // Quote character the value was wrapped in, or 0 when bare
+	Export bool // The line started with "export ", which is written back
 }
 
 // EnvFile models a .env file preserving comments and ordering.
@@ -40,24 +48,7 @@ func LoadEnvFile(path string) (*EnvFile, error) {
 
 	scanner := bufio.NewScanner(f)
 	for scanner.Scan() {
-		line := scanner.Text()
-		trimmed := strings.TrimSpace(line)
-		if trimmed == "" || strings.HasPrefix(trimmed, "#") || !strings.Contains(line, "=") {
-			file.Entries = append(file.Entries, EnvEntry{Raw: line})
-			continue
-		}
-
-		idx := strings.Index(line, "=")
-		key := strings.TrimSpace(line[:idx])
-		value := strings.TrimSpace(line[idx+1:])
-		if len(value) >= 2 {
-			first := value[0]
-			last := value[len(value)-1]
-			if (first == '"' && last == '"') || (first == '\'' && last == '\'') || (first == '`' && last == '`') {
-				value = value[1 : len(value)-1]
-			}
-		}
-		file.Entries = append(file.Entries, EnvEntry{Key: key, Value: value, IsPair: true})
+		file.Entries = append(file.Entries, ParseEnvLine(scanner.Text()))
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -67,6 +58,39 @@ func LoadEnvFile(path string) (*EnvFile, error) {
 	return file, nil
 }
 
+// ParseEnvLine parses one line of an env file, as LoadEnvFile does for every line.
+// Blank lines, comments and lines without '=' are kept raw. Otherwise the key and value
+// are trimmed, a leading "export " is dropped (and remembered in Export), and one pair
+// of matching surrounding quotes is removed from the value (and remembered in Quote).
+// A trailing carriage return from CRLF files is ignored. Text after an unquoted '#'
+// stays part of the value.
+func ParseEnvLine(line string) EnvEntry {
+	line = strings.TrimSuffix(line, "\r")
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") || !strings.Contains(line, "=") {
+		return EnvEntry{Raw: line}
+	}
+
+	idx := strings.Index(line, "=")
+	key := strings.TrimSpace(line[:idx])
+	export := false
+	if rest, ok := strings.CutPrefix(key, "export "); ok {
+		key = strings.TrimSpace(rest)
+		export = true
+	}
+	value := strings.TrimSpace(line[idx+1:])
+	var quote byte
+	if len(value) >= 2 {
+		first := value[0]
+		last := value[len(value)-1]
+		if (first == '"' && last == '"') || (first == '\'' && last == '\'') || (first == '`' && last == '`') {
+			value = value[1 : len(value)-1]
+			quote = first
+		}
+	}
+	return EnvEntry{Key: key, Value: value, IsPair: true, Quote: quote, Export: export}
+}
+
 // Get returns the value for a key if present.
 func (f *EnvFile) Get(key string) (string, bool) {
 	for _, entry := range f.Entries {
@@ -78,14 +102,46 @@ func (f *EnvFile) Get(key string) (string, bool) {
 }
 
 // Set inserts or updates a key in the env file representation.
+// Later duplicates of the key are dropped so the file keeps a single definition.
 func (f *EnvFile) Set(key, value string) {
-	for idx, entry := range f.Entries {
+	found := false
+	entries := f.Entries[:0]
+	for _, entry := range f.Entries {
 		if entry.IsPair && entry.Key == key {
-			f.Entries[idx].Value = value
-			return
+			if found {
+				continue
+			}
+			entry.Value = value
+			found = true
 		}
+		entries = append(entries, entry)
+	}
+	f.Entries = entries
+	if !found {
+		f.Entries = append(f.Entries, EnvEntry{Key: key, Value: value, IsPair: true})
 	}
-	f.Entries = append(f.Entries, EnvEntry{Key: key, Value: value, IsPair: true})
+}
+
+// Duplicates returns the keys defined more than once, in order of first appearance.
+func (f *EnvFile) Duplicates() []string {
+	counts := make(map[string]int)
+	var order []string
+	for _, entry := range f.Entries {
+		if !entry.IsPair {
+			continue
+		}
+		if counts[entry.Key] == 0 {
+			order = append(order, entry.Key)
+		}
+		counts[entry.Key]++
+	}
+	var dups []string
+	for _, key := range order {
+		if counts[key] > 1 {
+			dups = append(dups, key)
+		}
+	}
+	return dups
 }
 
 // Pairs returns a map of all key-value pairs.
@@ -99,20 +155,49 @@ func (f *EnvFile) Pairs() map[string]string {
 	return result
 }
 
-// Write persists the env file to disk.
+// formatValue renders the value for writing. Originally quoted values keep their quotes;
+// bare values are quoted when they contain whitespace, '#' or '=' so they read back unchanged.
+func (e EnvEntry) formatValue() string {
+	quote := e.Quote
+	if quote == 0 && strings.ContainsAny(e.Value, " \t#=") {
+		quote = '"'
+	}
+	if quote == 0 {
+		return e.Value
+	}
+	// Double quotes allow escapes in compose env files, so fall back to single quotes
+	if quote == '"' && strings.ContainsAny(e.Value, "\"\\") && !strings.ContainsRune(e.Value, '\'') {
+		quote = '\''
+	}
+	return string(quote) + e.Value + string(quote)
+}
+
+// Write persists the env file to disk. With --dry-run it only reports the write.
 func (f *EnvFile) Write() error {
 	if f.Path == "" {
 		return errors.New("env file path is empty")
 	}
+	if dryRun {
+		fmt.Printf("[dry-run] Would write %s\n", f.Path)
+		return nil
+	}
+
+	entries, err := f.sealEntries()
+	if err != nil {
+		return err
+	}
 
 	var builder strings.Builder
-	for idx, entry := range f.Entries {
+	for idx, entry := range entries {
 		if entry.IsPair {
-			builder.WriteString(fmt.Sprintf("%s=%s", entry.Key, entry.Value))
+			if entry.Export {
+				builder.WriteString("export ")
+			}
+			builder.WriteString(fmt.Sprintf("%s=%s", entry.Key, entry.formatValue()))
 		} else {
 			builder.WriteString(entry.Raw)
 		}
-		if idx < len(f.Entries)-1 {
+		if idx < len(entries)-1 {
 			builder.WriteString("\n")
 		}
 	}
@@ -127,6 +212,86 @@ func (f *EnvFile) Write() error {
 	return nil
 }
 
+// defaultEnvBackupCount is how many backups WriteWithBackup keeps unless LEYZEN_ENV_BACKUPS overrides it.
+const defaultEnvBackupCount = 5
+
+// WriteWithBackup copies the current file to a timestamped <path>.bak.<time>-<random> backup,
+// then writes the new contents. Only the newest LEYZEN_ENV_BACKUPS backups are kept
+// (default 5); setting it to 0 disables backups.
+func (f *EnvFile) WriteWithBackup() error {
+	if f.Path == "" {
+		return errors.New("env file path is empty")
+	}
+	if dryRun {
+		// Nothing is replaced, so there is nothing to back up
+		return f.Write()
+	}
+
+	keep := defaultEnvBackupCount
+	if raw := strings.TrimSpace(os.Getenv("LEYZEN_ENV_BACKUPS")); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n >= 0 {
+			keep = n
+		}
+	}
+
+	if keep > 0 {
+		current, err := os.ReadFile(f.Path)
+		switch {
+		case err == nil:
+			if err := writeEnvBackup(f.Path, current); err != nil {
+				return err
+			}
+			if err := pruneEnvBackups(f.Path, keep); err != nil {
+				return err
+			}
+		case !errors.Is(err, os.ErrNotExist):
+			return fmt.Errorf("read env file for backup: %w", err)
+		}
+	}
+
+	return f.Write()
+}
+
+// writeEnvBackup stores data as a new <path>.bak.<time>-<random> file. The random
+// suffix keeps two saves within the same millisecond from sharing a name, and the
+// file is created exclusively so an existing backup is never overwritten.
+func writeEnvBackup(path string, data []byte) error {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("back up env file: %w", err)
+	}
+	backupPath := fmt.Sprintf("%s.bak.%s-%s", path, time.Now().Format("20060102-150405.000"), hex.EncodeToString(suffix))
+	backup, err := os.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("back up env file: %w", err)
+	}
+	if _, err := backup.Write(data); err != nil {
+		backup.Close()
+		return fmt.Errorf("back up env file: %w", err)
+	}
+	if err := backup.Close(); err != nil {
+		return fmt.Errorf("back up env file: %w", err)
+	}
+	return nil
+}
+
+// pruneEnvBackups removes all but the newest keep backups of path.
+func pruneEnvBackups(path string, keep int) error {
+	backups, err := filepath.Glob(path + ".bak.*")
+	if err != nil {
+		return fmt.Errorf("list env backups: %w", err)
+	}
+	// Timestamps sort lexically, oldest first
+	sort.Strings(backups)
+	for len(backups) > keep {
+		if err := os.Remove(backups[0]); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove old env backup: %w", err)
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
 // ResolveEnvFilePath returns an absolute path for the provided env file, defaulting to .env when empty.
 func ResolveEnvFilePath(path string) (string, error) {
 	repoRoot, err := FindRepoRoot()
@@ -224,8 +389,75 @@ func InitializeEnvFromTemplate(envFilePath string) (map[string]string, error) {
 	return envFile.Pairs(), nil
 }
 
+// commentedAssignment matches an optional variable commented out in env.template.
+var commentedAssignment = regexp.MustCompile(`^#\s*([A-Z][A-Z0-9_]*=.*)$`)
+
+// ScaffoldEnvFile builds a new env file for envFilePath from env.template, with the
+// optional variables that the template comments out enabled. Nothing is written; the
+// returned set holds the keys that were optional.
+func ScaffoldEnvFile(envFilePath string) (*EnvFile, map[string]bool, error) {
+	resolved, err := ResolveEnvFilePath(envFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	templatePath, err := FindEnvTemplatePath(resolved)
+	if err != nil {
+		return nil, nil, fmt.Errorf("env.template not found next to %s: %w", resolved, err)
+	}
+	template, err := LoadEnvFile(templatePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load env template: %w", err)
+	}
+
+	defined := template.Pairs()
+	optional := make(map[string]bool)
+	file := &EnvFile{Path: resolved}
+	for i, entry := range template.Entries {
+		match := commentedAssignment.FindStringSubmatch(strings.TrimSpace(entry.Raw))
+		// Prose mentioning a variable continues on the next comment line; an assignment
+		// ends its comment block
+		if entry.IsPair || match == nil || !endsCommentBlock(template.Entries, i) {
+			file.Entries = append(file.Entries, entry)
+			continue
+		}
+		pair := ParseEnvLine(match[1])
+		if _, exists := defined[pair.Key]; exists {
+			file.Entries = append(file.Entries, entry)
+			continue
+		}
+		defined[pair.Key] = pair.Value
+		optional[pair.Key] = true
+		file.Entries = append(file.Entries, pair)
+	}
+	return file, optional, nil
+}
+
+// endsCommentBlock reports whether the comment at entries[i] is the last line of its
+// block: followed by a blank line, a variable, an annotation or another assignment.
+func endsCommentBlock(entries []EnvEntry, i int) bool {
+	if i+1 >= len(entries) {
+		return true
+	}
+	next := entries[i+1]
+	trimmed := strings.TrimSpace(next.Raw)
+	if next.IsPair || !strings.HasPrefix(trimmed, "#") {
+		return true
+	}
+	content := strings.TrimSpace(strings.TrimPrefix(trimmed, "#"))
+	return strings.HasPrefix(content, "@") || commentedAssignment.MatchString(trimmed)
+}
+
+// IsSecretKey reports whether a variable name looks like it holds a password, secret or token.
+func IsSecretKey(key string) bool {
+	lower := strings.ToLower(key)
+	return strings.Contains(lower, "password") ||
+		strings.Contains(lower, "secret") ||
+		strings.Contains(lower, "pass") ||
+		strings.Contains(lower, "token")
+}
+
 // LoadAllEnvVariables loads all environment variables by merging env.template with .env.
-// Values from .env take priority over template values.
+// Values from .env take priority over template values; encrypted secrets are decrypted.
 func LoadAllEnvVariables(envFilePath string) (map[string]string, error) {
 	templatePairs, err := LoadEnvTemplate(envFilePath)
 	if err != nil {
@@ -237,7 +469,10 @@ func LoadAllEnvVariables(envFilePath string) (map[string]string, error) {
 		return nil, fmt.Errorf("load env file: %w", err)
 	}
 
-	envPairs := envFile.Pairs()
+	envPairs, err := envFile.DecryptedPairs()
+	if err != nil {
+		return nil, err
+	}
 
 	result := make(map[string]string)
 	for key, value := range templatePairs {
@@ -255,8 +490,12 @@ type EnvDoc struct {
 	Name        string
 	Summary     string
 	Description string
+	Type        string // Value type declared with "# @type: <type>", empty when untyped
 }
 
+// envTypeAnnotation marks the comment line declaring the type of the next variable.
+const envTypeAnnotation = "@type:"
+
 // FindEnvTemplatePath locates the env.template file relative to the given env file path.
 func FindEnvTemplatePath(envFilePath string) (string, error) {
 	resolvedEnvPath, err := ResolveEnvFilePath(envFilePath)
@@ -286,7 +525,11 @@ func LoadEnvDocumentation(envFilePath string) (map[string]EnvDoc, error) {
 		}
 		return nil, fmt.Errorf("find env template: %w", err)
 	}
+	return loadTemplateDocumentation(templatePath)
+}
 
+// loadTemplateDocumentation parses the comments of the template at templatePath.
+func loadTemplateDocumentation(templatePath string) (map[string]EnvDoc, error) {
 	f, err := os.Open(templatePath)
 	if err != nil {
 		return nil, fmt.Errorf("open template: %w", err)
@@ -295,6 +538,22 @@ func LoadEnvDocumentation(envFilePath string) (map[string]EnvDoc, error) {
 
 	docs := make(map[string]EnvDoc)
 	var currentComments []string
+	currentType := ""
+
+	newDoc := func(key string) (EnvDoc, bool) {
+		if len(currentComments) == 0 && currentType == "" {
+			return EnvDoc{}, false
+		}
+		doc := EnvDoc{
+			Name:        key,
+			Description: strings.Join(currentComments, "\n"),
+			Type:        currentType,
+		}
+		if len(currentComments) > 0 {
+			doc.Summary = currentComments[0]
+		}
+		return doc, true
+	}
 
 	scanner := bufio.NewScanner(f)
 	for scanner.Scan() {
@@ -303,6 +562,7 @@ func LoadEnvDocumentation(envFilePath string) (map[string]EnvDoc, error) {
 
 		if trimmed == "" {
 			currentComments = nil
+			currentType = ""
 			continue
 		}
 
@@ -310,20 +570,21 @@ func LoadEnvDocumentation(envFilePath string) (map[string]EnvDoc, error) {
 			content := strings.TrimPrefix(trimmed, "#")
 			content = strings.TrimSpace(content)
 
+			if strings.HasPrefix(content, envTypeAnnotation) {
+				currentType = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(content, envTypeAnnotation)))
+				continue
+			}
+
 			if idx := strings.Index(content, "="); idx != -1 {
 				key := strings.TrimSpace(content[:idx])
 				if key != "" && !strings.Contains(key, " ") {
-					if len(currentComments) > 0 {
-						doc := EnvDoc{
-							Name:        key,
-							Summary:     currentComments[0],
-							Description: strings.Join(currentComments, "\n"),
-						}
+					if doc, ok := newDoc(key); ok {
 						if _, exists := docs[key]; !exists {
 							docs[key] = doc
 						}
 					}
 					currentComments = nil
+					currentType = ""
 					continue
 				}
 			}
@@ -333,25 +594,14 @@ func LoadEnvDocumentation(envFilePath string) (map[string]EnvDoc, error) {
 		}
 
 		if idx := strings.Index(trimmed, "="); idx != -1 {
-			if len(currentComments) > 0 {
-				key := strings.TrimSpace(trimmed[:idx])
-
-				if key == "" {
-					currentComments = nil
-					continue
-				}
-
-				doc := EnvDoc{
-					Name:        key,
-					Summary:     currentComments[0],
-					Description: strings.Join(currentComments, "\n"),
+			if key := strings.TrimSpace(trimmed[:idx]); key != "" {
+				if doc, ok := newDoc(key); ok {
+					docs[key] = doc
 				}
-				docs[key] = doc
 			}
-			currentComments = nil
-		} else {
-			currentComments = nil
 		}
+		currentComments = nil
+		currentType = ""
 	}
 
 	if err := scanner.Err(); err != nil {
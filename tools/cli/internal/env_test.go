@@ -0,0 +1,220 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeEnv writes content to a .env file in a fresh temporary directory and returns its path.
+func writeEnv(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readEnv(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestEnvFileDuplicatesAndSetCollapse(t *testing.T) {
+	path := writeEnv(t, "HTTP_PORT=8080\nSECRET_KEY=abc\n# comment\nHTTP_PORT=9090\nTIMEZONE=UTC\nHTTP_PORT=7070\n")
+
+	file, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := file.Duplicates(), []string{"HTTP_PORT"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Duplicates() = %v, want %v", got, want)
+	}
+
+	file.Set("HTTP_PORT", "8443")
+	if err := file.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "HTTP_PORT=8443\nSECRET_KEY=abc\n# comment\nTIMEZONE=UTC\n"
+	if got := readEnv(t, path); got != want {
+		t.Fatalf("written file = %q, want %q", got, want)
+	}
+
+	reloaded, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dups := reloaded.Duplicates(); len(dups) != 0 {
+		t.Fatalf("Duplicates() after Set = %v, want none", dups)
+	}
+}
+
+func TestEnvFileQuotedValuesRoundTrip(t *testing.T) {
+	values := map[string]string{
+		"HASH":          "pass#word",
+		"EQUALS":        "a=b=c",
+		"SPACES":        "two words",
+		"PADDED":        "  padded  ",
+		"DOUBLE_QUOTES": `say "hi"`,
+		"SINGLE_QUOTE":  "it's",
+		"BACKSLASH":     `C:\path`,
+		"PLAIN":         "plain",
+	}
+
+	path := writeEnv(t, "")
+	file, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"HASH", "EQUALS", "SPACES", "PADDED", "DOUBLE_QUOTES", "SINGLE_QUOTE", "BACKSLASH", "PLAIN"} {
+		file.Set(key, values[key])
+	}
+	if err := file.Write(); err != nil {
+		t.Fatal(err)
+	}
+	first := readEnv(t, path)
+
+	reloaded, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range values {
+		if got, _ := reloaded.Get(key); got != want {
+			t.Errorf("%s = %q after round trip, want %q", key, got, want)
+		}
+	}
+
+	// A second write of the reloaded file must not change the quoting
+	if err := reloaded.Write(); err != nil {
+		t.Fatal(err)
+	}
+	if second := readEnv(t, path); second != first {
+		t.Errorf("second write changed the file:\n%s\n---\n%s", first, second)
+	}
+}
+
+func TestEnvFileKeepsOriginalQuotes(t *testing.T) {
+	content := "A='single'\nB=\"double\"\nC=bare\n"
+	path := writeEnv(t, content)
+	file, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Write(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readEnv(t, path); got != content {
+		t.Errorf("rewritten file = %q, want %q", got, content)
+	}
+}
+
+func TestEnvFileWriteHonorsDryRun(t *testing.T) {
+	SetDryRun(true)
+	t.Cleanup(func() { SetDryRun(false) })
+
+	content := "HTTP_PORT=8080\n"
+	path := writeEnv(t, content)
+	file, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Set("HTTP_PORT", "9090")
+	if err := file.WriteWithBackup(); err != nil {
+		t.Fatal(err)
+	}
+	if got := readEnv(t, path); got != content {
+		t.Errorf("dry run rewrote the file: %q", got)
+	}
+	if backups, _ := filepath.Glob(path + ".bak.*"); len(backups) != 0 {
+		t.Errorf("dry run created backups: %v", backups)
+	}
+}
+
+func TestEnvFileBackupsDoNotCollide(t *testing.T) {
+	t.Setenv("LEYZEN_ENV_BACKUPS", "10")
+	path := writeEnv(t, "HTTP_PORT=8080\n")
+	file, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Back-to-back saves usually land in the same millisecond
+	for i := 0; i < 3; i++ {
+		if err := file.WriteWithBackup(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if backups, _ := filepath.Glob(path + ".bak.*"); len(backups) != 3 {
+		t.Errorf("got %d backups, want 3: %v", len(backups), backups)
+	}
+}
+
+func TestParseEnvLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want EnvEntry
+	}{
+		{"plain", "HTTP_PORT=8080", EnvEntry{Key: "HTTP_PORT", Value: "8080", IsPair: true}},
+		{"spaces around", "  HTTP_PORT = 8080  ", EnvEntry{Key: "HTTP_PORT", Value: "8080", IsPair: true}},
+		{"export prefix", "export HTTP_PORT=8080", EnvEntry{Key: "HTTP_PORT", Value: "8080", IsPair: true, Export: true}},
+		{"export with extra spaces", "export   HTTP_PORT=8080", EnvEntry{Key: "HTTP_PORT", Value: "8080", IsPair: true, Export: true}},
+		{"crlf", "HTTP_PORT=8080\r", EnvEntry{Key: "HTTP_PORT", Value: "8080", IsPair: true}},
+		{"crlf quoted", "SECRET_KEY=\"abc\"\r", EnvEntry{Key: "SECRET_KEY", Value: "abc", IsPair: true, Quote: '"'}},
+		{"double quoted hash", `PASSWORD="pass#word"`, EnvEntry{Key: "PASSWORD", Value: "pass#word", IsPair: true, Quote: '"'}},
+		{"single quoted hash", "PASSWORD='pass # word'", EnvEntry{Key: "PASSWORD", Value: "pass # word", IsPair: true, Quote: '\''}},
+		{"unquoted hash", "PASSWORD=pass#word", EnvEntry{Key: "PASSWORD", Value: "pass#word", IsPair: true}},
+		{"mismatched quotes", `NAME="value'`, EnvEntry{Key: "NAME", Value: `"value'`, IsPair: true}},
+		{"equals in value", "EQUALS=a=b=c", EnvEntry{Key: "EQUALS", Value: "a=b=c", IsPair: true}},
+		{"empty value", "TIMEZONE=", EnvEntry{Key: "TIMEZONE", IsPair: true}},
+		{"comment", "# HTTP_PORT=8080", EnvEntry{Raw: "# HTTP_PORT=8080"}},
+		{"indented comment", "  # note", EnvEntry{Raw: "  # note"}},
+		{"blank", "", EnvEntry{Raw: ""}},
+		{"whitespace only", "   ", EnvEntry{Raw: "   "}},
+		{"blank crlf", "\r", EnvEntry{Raw: ""}},
+		{"no equals", "not a pair", EnvEntry{Raw: "not a pair"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseEnvLine(tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEnvLine(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvFileExportLines(t *testing.T) {
+	root := chdirRepo(t, "# @type: port\nHTTP_PORT=\n")
+	path := writeRepoFile(t, root, ".env", "export HTTP_PORT=8080\nexport SECRET_KEY='abc'\nTIMEZONE=UTC\n")
+
+	file, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, ok := file.Get("HTTP_PORT")
+	if !ok || value != "8080" {
+		t.Fatalf("Get(HTTP_PORT) = %q, %v, want 8080", value, ok)
+	}
+	if _, err := ValidateEnvValue(path, "HTTP_PORT", value); err != nil {
+		t.Errorf("ValidateEnvValue(HTTP_PORT=%s) = %v, want valid", value, err)
+	}
+	if _, err := ValidateEnvValue(path, "HTTP_PORT", "not-a-port"); err == nil {
+		t.Error("ValidateEnvValue(HTTP_PORT=not-a-port) passed, want the port type from env.template applied")
+	}
+
+	file.Set("HTTP_PORT", "9090")
+	file.Set("LOG_LEVEL", "debug")
+	if err := file.Write(); err != nil {
+		t.Fatal(err)
+	}
+	want := "export HTTP_PORT=9090\nexport SECRET_KEY='abc'\nTIMEZONE=UTC\nLOG_LEVEL=debug\n"
+	if got := readEnv(t, path); got != want {
+		t.Errorf("written file = %q, want %q", got, want)
+	}
+}
@@ -1,32 +1,304 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
+	"gopkg.in/yaml.v3"
+
 	"leyzenctl/internal/compose"
 )
 
+const composeHeader = `# ==================================================================================
+# WARNING: This file is auto-generated by leyzenctl config generate
+# Do NOT edit this file manually.
+# ==================================================================================
+
+`
+
+// GeneratedConfig holds the rendered HAProxy and Compose files and where they belong.
+// Secrets is nil when the env file stores no encrypted secret; a stale secrets file is
+// then removed.
+type GeneratedConfig struct {
+	HAProxyPath string
+	HAProxy     []byte
+	ComposePath string
+	Compose     []byte
+	SecretsPath string
+	Secrets     []byte
+}
+
+// showGeneratedDiff controls whether GenerateConfig prints the changes it made to
+// docker-generated.yml. Disabled by --quiet and inside the dashboard.
+var showGeneratedDiff = true
+
+// SetGeneratedDiffOutput enables or disables the regeneration diff.
+func SetGeneratedDiffOutput(enabled bool) {
+	showGeneratedDiff = enabled
+}
+
+// BuildConfigError reports that the configuration could not be generated, as opposed
+// to a docker compose failure. docker-generated.yml is left as it was.
+type BuildConfigError struct {
+	Err error
+}
+
+func (e *BuildConfigError) Error() string {
+	return "configuration generation failed: " + e.Err.Error()
+}
+
+func (e *BuildConfigError) Unwrap() error {
+	return e.Err
+}
+
+// MissingVariable names the env variable whose absence stopped the generation, or
+// returns "" when the failure has another cause.
+func (e *BuildConfigError) MissingVariable() string {
+	var missing *compose.MissingVariableError
+	if errors.As(e.Err, &missing) {
+		return missing.Name
+	}
+	return ""
+}
+
+// GenerateConfig renders and writes haproxy.cfg and docker-generated.yml. Errors are
+// returned as *BuildConfigError.
 func GenerateConfig(stdout, stderr io.Writer, envFile string) error {
+	if err := generateConfig(stdout, envFile); err != nil {
+		return &BuildConfigError{Err: err}
+	}
+	return nil
+}
+
+func generateConfig(stdout io.Writer, envFile string) error {
+	cfg, err := RenderConfig(stdout, envFile)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		fmt.Fprintf(stdout, "[dry-run] Would write %s\n", cfg.HAProxyPath)
+		fmt.Fprintf(stdout, "[dry-run] Would write %s\n", cfg.ComposePath)
+		if cfg.Secrets != nil {
+			fmt.Fprintf(stdout, "[dry-run] Would write %s (mode 0600)\n", cfg.SecretsPath)
+		}
+		if previous, err := os.ReadFile(cfg.ComposePath); err == nil && showGeneratedDiff {
+			if diff := UnifiedDiff("docker-generated.yml (current)", "docker-generated.yml (generated)", string(previous), string(cfg.Compose)); diff != "" {
+				fmt.Fprintln(stdout, "[compose] Changes:")
+				fmt.Fprintln(stdout, ColorizeDiff(diff))
+			}
+		}
+		return nil
+	}
+
+	// Check both files and stage them next to their targets before replacing either, so a
+	// failure leaves the previous haproxy.cfg and docker-generated.yml in place as a pair
+	if len(strings.TrimSpace(string(cfg.HAProxy))) == 0 {
+		return errors.New("generated haproxy.cfg is empty")
+	}
+	var manifest compose.Manifest
+	if err := yaml.Unmarshal(cfg.Compose, &manifest); err != nil {
+		return fmt.Errorf("generated docker-generated.yml is not valid YAML: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.HAProxyPath), 0755); err != nil {
+		return fmt.Errorf("failed to create haproxy config dir: %w", err)
+	}
+
+	staged := []*stagedFile{
+		{path: cfg.HAProxyPath, data: cfg.HAProxy, perm: 0644},
+		{path: cfg.ComposePath, data: cfg.Compose, perm: 0644},
+	}
+	if cfg.Secrets != nil {
+		staged = append(staged, &stagedFile{path: cfg.SecretsPath, data: cfg.Secrets, perm: 0600})
+	}
+	// Temporary files left by a failure are removed; committed ones no longer exist
+	defer func() {
+		for _, file := range staged {
+			file.discard()
+		}
+	}()
+	for _, file := range staged {
+		if err := file.stage(); err != nil {
+			return fmt.Errorf("failed to write %s: %w", filepath.Base(file.path), err)
+		}
+	}
+
+	previous, readErr := os.ReadFile(cfg.ComposePath)
+	if err := commitStaged(stdout, staged); err != nil {
+		return err
+	}
+	if cfg.Secrets == nil {
+		if err := os.Remove(cfg.SecretsPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", filepath.Base(cfg.SecretsPath), err)
+		}
+	}
+	fmt.Fprintf(stdout, "[compose] Wrote %s\n\n", cfg.ComposePath)
+
+	// Show what a regeneration changed; a first generation has nothing to compare against
+	if showGeneratedDiff && readErr == nil {
+		diff := UnifiedDiff("docker-generated.yml (previous)", "docker-generated.yml (generated)", string(previous), string(cfg.Compose))
+		if diff != "" {
+			fmt.Fprintln(stdout, "[compose] Changes:")
+			fmt.Fprintln(stdout, ColorizeDiff(diff))
+		}
+	}
+
+	return nil
+}
+
+// commitStaged renames every staged file into place. Each rename is atomic but the
+// set is not, so when one fails the files already replaced get their previous
+// contents back.
+func commitStaged(stdout io.Writer, staged []*stagedFile) error {
+	for _, file := range staged {
+		if err := file.snapshot(); err != nil {
+			return fmt.Errorf("failed to read %s: %w", filepath.Base(file.path), err)
+		}
+	}
+	for i, file := range staged {
+		if err := file.commit(); err != nil {
+			for _, done := range staged[:i] {
+				if rbErr := done.rollback(); rbErr != nil {
+					fmt.Fprintf(stdout, "[compose] Failed to restore %s: %v\n", done.path, rbErr)
+				}
+			}
+			return fmt.Errorf("failed to write %s: %w", filepath.Base(file.path), err)
+		}
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it into
+// place, so an interrupted write leaves either the previous file or the new one.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	file := &stagedFile{path: path, data: data, perm: perm}
+	// No-op once the rename succeeded
+	defer file.discard()
+
+	if err := file.stage(); err != nil {
+		return err
+	}
+	return file.commit()
+}
+
+// renameFile is os.Rename, replaced by tests to simulate a failed write.
+var renameFile = os.Rename
+
+// stagedFile is a file written to a temporary name next to its target, then renamed
+// into place by commit. Renames within a directory are atomic.
+type stagedFile struct {
+	path string
+	data []byte
+	perm os.FileMode
+	tmp  string
+
+	// Contents of path before commit, recorded by snapshot for rollback
+	previous     []byte
+	previousPerm os.FileMode
+	existed      bool
+}
+
+func (f *stagedFile) stage() error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), "."+filepath.Base(f.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	f.tmp = tmp.Name()
+
+	if _, err := tmp.Write(f.data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Chmod(f.tmp, f.perm)
+}
+
+func (f *stagedFile) commit() error {
+	if err := renameFile(f.tmp, f.path); err != nil {
+		return err
+	}
+	f.tmp = ""
+	return nil
+}
+
+// snapshot records the current contents of the target so rollback can restore them.
+func (f *stagedFile) snapshot() error {
+	info, err := os.Stat(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		f.existed = false
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	f.previous, f.previousPerm, f.existed = data, info.Mode().Perm(), true
+	return nil
+}
+
+// rollback puts back the contents recorded by snapshot after a commit, removing the
+// file when it did not exist before.
+func (f *stagedFile) rollback() error {
+	if !f.existed {
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return writeFileAtomic(f.path, f.previous, f.previousPerm)
+}
+
+// discard removes the temporary file of a file that was not committed.
+func (f *stagedFile) discard() {
+	if f.tmp != "" {
+		os.Remove(f.tmp)
+		f.tmp = ""
+	}
+}
+
+// RenderConfig renders the generated files without writing them. The SSL bundle is
+// still prepared when HTTPS is enabled because the manifest mounts it.
+func RenderConfig(stdout io.Writer, envFile string) (*GeneratedConfig, error) {
 	resolvedEnvPath, err := ResolveEnvFilePath(envFile)
 	if err != nil {
-		return fmt.Errorf("failed to resolve env file path: %w", err)
+		return nil, fmt.Errorf("failed to resolve env file path: %w", err)
 	}
 
-	env, err := loadEnvWithPriority(resolvedEnvPath)
+	env, decryptedSecrets, err := loadEnvWithPriority(resolvedEnvPath)
 	if err != nil {
-		return fmt.Errorf("failed to load environment: %w", err)
+		return nil, fmt.Errorf("failed to load environment: %w", err)
+	}
+
+	// The manifest scopes container, volume and network names to the project selected with -p
+	if composeProjectName != "" {
+		env["COMPOSE_PROJECT_NAME"] = composeProjectName
 	}
 
 	webContainers, _ := resolveWebContainers(env)
 
-	enableHTTPS := isTrue(env["ENABLE_HTTPS"])
+	for _, w := range compose.ConfigWarnings(env) {
+		fmt.Fprintf(stdout, "[warning] %s\n", w)
+	}
+
+	enableHTTPS := IsTrue(env["ENABLE_HTTPS"])
 	sslCertPath := env["SSL_CERT_PATH"]
 	sslKeyPath := env["SSL_KEY_PATH"]
+	sslChainPath := env["SSL_CHAIN_PATH"]
 
 	httpPort := parsePort(env["HTTP_PORT"], 8080)
 	httpsPort := parsePort(env["HTTPS_PORT"], 8443)
@@ -40,6 +312,9 @@ func GenerateConfig(stdout, stderr io.Writer, envFile string) error {
 		if sslCertPath != "" {
 			fmt.Fprintf(stdout, "[haproxy] SSL certificate: %s\n", sslCertPath)
 		}
+		if sslChainPath != "" {
+			fmt.Fprintf(stdout, "[haproxy] SSL chain: %s\n", sslChainPath)
+		}
 	} else {
 		fmt.Fprintln(stdout, "[haproxy] HTTPS: disabled")
 	}
@@ -48,18 +323,20 @@ func GenerateConfig(stdout, stderr io.Writer, envFile string) error {
 	if enableHTTPS && sslCertPath != "" {
 		repoRoot, err := FindRepoRoot()
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		bundlePath, warnings, err := compose.PrepareSSLCertificateBundle(
 			enableHTTPS,
 			sslCertPath,
 			sslKeyPath,
+			sslChainPath,
 			repoRoot,
 			"",
+			dryRun,
 		)
 		if err != nil {
-			return fmt.Errorf("failed to prepare SSL bundle: %w", err)
+			return nil, fmt.Errorf("failed to prepare SSL bundle: %w", err)
 		}
 
 		for _, w := range warnings {
@@ -69,6 +346,9 @@ func GenerateConfig(stdout, stderr io.Writer, envFile string) error {
 		if bundlePath != "" {
 			sslBundlePath = bundlePath
 			fmt.Fprintf(stdout, "[haproxy] SSL bundle: %s\n", sslBundlePath)
+			if dryRun {
+				fmt.Fprintf(stdout, "[dry-run] Would write %s\n", sslBundlePath)
+			}
 		}
 	}
 
@@ -79,25 +359,23 @@ func GenerateConfig(stdout, stderr io.Writer, envFile string) error {
 		sslCertPathContainer = "/usr/local/etc/haproxy/ssl/cert.pem"
 	}
 
+	backend := compose.HAProxyBackendFromEnv(env)
+	if backend.Balance != "" {
+		fmt.Fprintf(stdout, "[haproxy] Balance: %s\n", backend.Balance)
+	}
+
 	haproxyConfig := compose.RenderHAProxyConfig(
 		webContainers,
 		compose.VaultWebPort,
 		enableHTTPS,
 		sslCertPathContainer,
 		orchestratorEnabled,
+		backend,
 	)
 
 	repoRoot, err := FindRepoRoot()
 	if err != nil {
-		return err
-	}
-
-	haproxyPath := filepath.Join(repoRoot, "infra", "haproxy", "haproxy.cfg")
-	if err := os.MkdirAll(filepath.Dir(haproxyPath), 0755); err != nil {
-		return fmt.Errorf("failed to create haproxy config dir: %w", err)
-	}
-	if err := os.WriteFile(haproxyPath, []byte(haproxyConfig), 0644); err != nil {
-		return fmt.Errorf("failed to write haproxy config: %w", err)
+		return nil, err
 	}
 
 	backendSummary := ""
@@ -112,31 +390,52 @@ func GenerateConfig(stdout, stderr io.Writer, envFile string) error {
 		fmt.Fprintf(stdout, "[haproxy] Backends: %s\n", backendSummary)
 	}
 
-	manifestBytes, err := compose.BuildComposeManifest(env, webContainers, sslBundlePath, envFile)
+	manifestBytes, secretsEnv, err := compose.BuildComposeManifest(env, webContainers, sslBundlePath, envFile, decryptedSecrets)
 	if err != nil {
-		return fmt.Errorf("failed to build compose manifest: %w", err)
+		return nil, fmt.Errorf("failed to build compose manifest: %w", err)
 	}
 
-	composePath := filepath.Join(repoRoot, "docker-generated.yml")
-	header := `# ==================================================================================
-# WARNING: This file is auto-generated by leyzenctl config generate
-# Do NOT edit this file manually.
-# ==================================================================================
+	return &GeneratedConfig{
+		HAProxyPath: filepath.Join(repoRoot, "infra", "haproxy", "haproxy.cfg"),
+		HAProxy:     []byte(haproxyConfig),
+		ComposePath: filepath.Join(repoRoot, "docker-generated.yml"),
+		Compose:     append([]byte(composeHeader), manifestBytes...),
+		SecretsPath: filepath.Join(repoRoot, compose.SecretsEnvFile),
+		Secrets:     renderSecretsEnv(secretsEnv),
+	}, nil
+}
 
-`
-	finalContent := append([]byte(header), manifestBytes...)
-	if err := os.WriteFile(composePath, finalContent, 0644); err != nil {
-		return fmt.Errorf("failed to write docker-generated.yml: %w", err)
+// renderSecretsEnv formats the runtime secrets as an env file with sorted keys, quoted
+// like EnvFile.Write quotes values. It returns nil when there is nothing to write.
+func renderSecretsEnv(secrets map[string]string) []byte {
+	if len(secrets) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(secrets))
+	for key := range secrets {
+		keys = append(keys, key)
 	}
-	fmt.Fprintf(stdout, "[compose] Wrote %s\n\n", composePath)
+	sort.Strings(keys)
 
-	return nil
+	var sb strings.Builder
+	sb.WriteString("# Generated by leyzenctl from the encrypted secrets of the env file. Do not edit.\n")
+	for _, key := range keys {
+		entry := EnvEntry{Key: key, Value: secrets[key], IsPair: true}
+		fmt.Fprintf(&sb, "%s=%s\n", key, entry.formatValue())
+	}
+	return []byte(sb.String())
 }
 
-func loadEnvWithPriority(envFile string) (map[string]string, error) {
+// loadEnvWithPriority merges the process environment with the env file, whose values
+// win. It also returns the decrypted values of the secrets stored encrypted in the file.
+func loadEnvWithPriority(envFile string) (map[string]string, map[string]string, error) {
 	fileEnv, err := LoadEnvFile(envFile)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	filePairs, err := fileEnv.DecryptedPairs()
+	if err != nil {
+		return nil, nil, err
 	}
 
 	envMap := make(map[string]string)
@@ -148,18 +447,22 @@ func loadEnvWithPriority(envFile string) (map[string]string, error) {
 		}
 	}
 
-	for _, entry := range fileEnv.Entries {
-		if entry.IsPair {
-			envMap[entry.Key] = entry.Value
-		}
+	for key, value := range filePairs {
+		envMap[key] = value
+	}
+
+	decryptedSecrets := make(map[string]string)
+	for _, key := range fileEnv.EncryptedKeys() {
+		decryptedSecrets[key] = filePairs[key]
 	}
 
-	return envMap, nil
+	return envMap, decryptedSecrets, nil
 }
 
 func resolveWebContainers(env map[string]string) ([]string, string) {
 	if !isOrchestratorEnabled(env) {
-		return []string{"vault_app"}, "vault_app"
+		app := compose.VaultAppName(env)
+		return []string{app}, app
 	}
 
 	if val := strings.TrimSpace(env["ORCH_WEB_CONTAINERS"]); val != "" {
@@ -181,9 +484,10 @@ func resolveWebContainers(env map[string]string) ([]string, string) {
 		}
 	}
 
+	prefix := compose.VaultWebPrefix(env)
 	var names []string
 	for i := 0; i < replicas; i++ {
-		names = append(names, fmt.Sprintf("vault_web%d", i+1))
+		names = append(names, fmt.Sprintf("%s%d", prefix, i+1))
 	}
 	return names, strings.Join(names, ",")
 }
@@ -212,7 +516,8 @@ func isOrchestratorEnabled(env map[string]string) bool {
 	return val == "true" || val == "1" || val == "yes" || val == "on"
 }
 
-func isTrue(val string) bool {
+// IsTrue reports whether an env toggle such as ENABLE_HTTPS is switched on.
+func IsTrue(val string) bool {
 	val = strings.ToLower(strings.TrimSpace(val))
 	return val == "true" || val == "1" || val == "yes" || val == "on"
 }
@@ -0,0 +1,121 @@
+package internal
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWriteFileAtomicKeepsPreviousFileOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "docker-generated.yml")
+	previous := "services: {}\n"
+	if err := os.WriteFile(path, []byte(previous), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	renameFile = func(string, string) error { return errors.New("simulated rename failure") }
+	t.Cleanup(func() { renameFile = os.Rename })
+
+	if err := writeFileAtomic(path, []byte("services:\n  truncat"), 0o644); err == nil {
+		t.Fatal("writeFileAtomic succeeded although the rename failed")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != previous {
+		t.Errorf("previous file changed to %q", data)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		t.Errorf("temporary files left behind: %v", names)
+	}
+}
+
+func TestWriteFileAtomicReplacesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "haproxy.cfg")
+	if err := os.WriteFile(path, []byte("old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(path, []byte("new\n"), 0o600); err != nil {
+		t.Fatalf("writeFileAtomic: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+	if data, _ := os.ReadFile(path); string(data) != "new\n" {
+		t.Errorf("content = %q, want %q", data, "new\n")
+	}
+}
+
+func TestCommitStagedRestoresPairOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	haproxyPath := filepath.Join(dir, "haproxy.cfg")
+	composePath := filepath.Join(dir, "docker-generated.yml")
+	if err := os.WriteFile(haproxyPath, []byte("old haproxy\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(composePath, []byte("old compose\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the second file of the pair fails to land
+	renameFile = func(from, to string) error {
+		if to == composePath {
+			return errors.New("simulated rename failure")
+		}
+		return os.Rename(from, to)
+	}
+	t.Cleanup(func() { renameFile = os.Rename })
+
+	staged := []*stagedFile{
+		{path: haproxyPath, data: []byte("new haproxy\n"), perm: 0o644},
+		{path: composePath, data: []byte("new compose\n"), perm: 0o644},
+	}
+	for _, file := range staged {
+		if err := file.stage(); err != nil {
+			t.Fatal(err)
+		}
+		defer file.discard()
+	}
+	if err := commitStaged(io.Discard, staged); err == nil {
+		t.Fatal("commitStaged succeeded although a rename failed")
+	}
+	for path, want := range map[string]string{haproxyPath: "old haproxy\n", composePath: "old compose\n"} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != want {
+			t.Errorf("%s = %q, want %q", filepath.Base(path), data, want)
+		}
+	}
+}
+
+func TestResolveWebContainersUsesCustomNames(t *testing.T) {
+	env := map[string]string{"ORCHESTRATOR_ENABLED": "true", "WEB_REPLICAS": "2", "VAULT_WEB_PREFIX": "web"}
+	names, joined := resolveWebContainers(env)
+	if want := []string{"web1", "web2"}; !reflect.DeepEqual(names, want) || joined != "web1,web2" {
+		t.Errorf("resolveWebContainers() = %v, %q, want %v", names, joined, want)
+	}
+
+	env = map[string]string{"ORCHESTRATOR_ENABLED": "false", "VAULT_APP_NAME": "vault"}
+	if names, _ := resolveWebContainers(env); !reflect.DeepEqual(names, []string{"vault"}) {
+		t.Errorf("resolveWebContainers() = %v, want [vault]", names)
+	}
+}
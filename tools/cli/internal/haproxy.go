@@ -0,0 +1,46 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"leyzenctl/internal/compose"
+)
+
+const haproxyConfigContainerPath = "/usr/local/etc/haproxy/haproxy.cfg"
+
+// ValidateHAProxyConfig runs `haproxy -c` against the generated configuration in a
+// throwaway container that uses the image and mounts of the haproxy service.
+func ValidateHAProxyConfig(stdout, stderr io.Writer) error {
+	manifest, err := LoadGeneratedManifest()
+	if err != nil {
+		return err
+	}
+	service, ok := manifest.Services[compose.HAProxyContainerName]
+	if !ok {
+		return fmt.Errorf("service %s not found in docker-generated.yml", compose.HAProxyContainerName)
+	}
+
+	repoRoot, err := FindRepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find repository root: %w", err)
+	}
+
+	// Backends use init-addr none, so the check does not need the stack network
+	args := []string{"run", "--rm", "--network", "none"}
+	for _, volume := range service.Volumes {
+		source, target, ok := strings.Cut(volume, ":")
+		if !ok {
+			continue
+		}
+		if !filepath.IsAbs(source) {
+			source = filepath.Join(repoRoot, source)
+		}
+		args = append(args, "-v", source+":"+target)
+	}
+	args = append(args, "--entrypoint", "haproxy", service.Image, "-c", "-f", haproxyConfigContainerPath)
+
+	return runStreaming(stdout, stderr, args)
+}
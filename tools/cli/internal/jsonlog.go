@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogEvent is one line of --log-json output.
+type LogEvent struct {
+	TS      time.Time `json:"ts"`
+	Action  string    `json:"action"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+}
+
+// WriteLogEvent writes a single JSON event. Color codes are stripped from the message.
+func WriteLogEvent(w io.Writer, action, level, message string) {
+	message = strings.TrimSpace(ansiRegex.ReplaceAllString(message, ""))
+	if message == "" {
+		return
+	}
+	b, err := json.Marshal(LogEvent{TS: time.Now().UTC(), Action: action, Level: level, Message: message})
+	if err != nil {
+		return
+	}
+	w.Write(append(b, '\n'))
+}
+
+// JSONLogWriter turns streamed command output into one "info" event per line, so
+// docker compose and generator output can be embedded in a JSON log.
+type JSONLogWriter struct {
+	out    io.Writer
+	action string
+	mu     sync.Mutex
+	buf    strings.Builder
+}
+
+// NewJSONLogWriter returns a writer emitting events for action to out.
+func NewJSONLogWriter(out io.Writer, action string) *JSONLogWriter {
+	return &JSONLogWriter{out: out, action: action}
+}
+
+func (w *JSONLogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf.Write(p)
+	data := w.buf.String()
+	w.buf.Reset()
+	for {
+		idx := strings.IndexAny(data, "\r\n")
+		if idx == -1 {
+			w.buf.WriteString(data)
+			break
+		}
+		WriteLogEvent(w.out, w.action, "info", data[:idx])
+		data = data[idx+1:]
+	}
+	return len(p), nil
+}
+
+// Flush emits any buffered partial line.
+func (w *JSONLogWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	WriteLogEvent(w.out, w.action, "info", w.buf.String())
+	w.buf.Reset()
+}
@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteLogEventLineShape(t *testing.T) {
+	var buf bytes.Buffer
+	WriteLogEvent(&buf, "start", "info", "\x1b[32mStarting vault\x1b[0m\n")
+	WriteLogEvent(&buf, "start", "warn", "haproxy not ready")
+	// Blank messages, including color codes only, are dropped
+	WriteLogEvent(&buf, "start", "info", "  \x1b[0m ")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+
+	want := []LogEvent{
+		{Action: "start", Level: "info", Message: "Starting vault"},
+		{Action: "start", Level: "warn", Message: "haproxy not ready"},
+	}
+	for i, line := range lines {
+		var fields map[string]any
+		if err := json.Unmarshal([]byte(line), &fields); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v\n%s", i+1, err, line)
+		}
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		if wantKeys := []string{"action", "level", "message", "ts"}; !reflect.DeepEqual(keys, wantKeys) {
+			t.Errorf("line %d has fields %v, want %v", i+1, keys, wantKeys)
+		}
+
+		var event LogEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatal(err)
+		}
+		if event.TS.IsZero() || event.TS.Location() != time.UTC {
+			t.Errorf("line %d has ts %v, want a UTC time", i+1, event.TS)
+		}
+		event.TS = time.Time{}
+		if event != want[i] {
+			t.Errorf("line %d = %+v, want %+v", i+1, event, want[i])
+		}
+	}
+}
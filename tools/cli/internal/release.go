@@ -0,0 +1,226 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	latestReleaseURL = "https://api.github.com/repos/3xpyth0n/leyzen-vault/releases/latest"
+	releaseByTagURL  = "https://api.github.com/repos/3xpyth0n/leyzen-vault/releases/tags/"
+	releaseCacheTTL  = 6 * time.Hour
+	releaseCacheFile = "latest-release.json"
+
+	// checksumsAsset is the sha256 list GoReleaser attaches to every release
+	checksumsAsset  = "checksums.txt"
+	downloadTimeout = 5 * time.Minute
+)
+
+type releaseCache struct {
+	Tag       string    `json:"tag"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// LatestRelease returns the tag of the latest GitHub release, or "" when it cannot be
+// determined. Lookups are cached in the user cache dir for releaseCacheTTL so repeated
+// invocations (e.g. in CI) do not hit the GitHub rate limit; refresh bypasses the cache.
+func LatestRelease(refresh bool) string {
+	path := releaseCachePath()
+	if !refresh && path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			var cache releaseCache
+			if json.Unmarshal(data, &cache) == nil && cache.Tag != "" && time.Since(cache.FetchedAt) < releaseCacheTTL {
+				return cache.Tag
+			}
+		}
+	}
+
+	tag := fetchLatestRelease()
+	if tag != "" && path != "" {
+		// The cache is an optimization; failing to write it is not an error
+		if data, err := json.Marshal(releaseCache{Tag: tag, FetchedAt: time.Now()}); err == nil {
+			if os.MkdirAll(filepath.Dir(path), 0o755) == nil {
+				_ = os.WriteFile(path, data, 0o644)
+			}
+		}
+	}
+	return tag
+}
+
+func releaseCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "leyzenctl", releaseCacheFile)
+}
+
+// fetchLatestRelease queries the GitHub API with a short timeout, best effort.
+func fetchLatestRelease() string {
+	client := &http.Client{Timeout: 3 * time.Second}
+	req, err := http.NewRequest("GET", latestReleaseURL, nil)
+	if err != nil {
+		return ""
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+	var lr struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&lr); err != nil {
+		return ""
+	}
+	return lr.TagName
+}
+
+// ReleaseAsset is a file attached to a GitHub release.
+type ReleaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// ReleaseAssets lists the files attached to the release with the given tag.
+func ReleaseAssets(tag string) ([]ReleaseAsset, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	req, err := http.NewRequest("GET", releaseByTagURL+tag, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query release %s: %w", tag, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query release %s: GitHub returned %s", tag, resp.Status)
+	}
+	var release struct {
+		Assets []ReleaseAsset `json:"assets"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to parse release %s: %w", tag, err)
+	}
+	return release.Assets, nil
+}
+
+// PlatformAsset picks the leyzenctl binary built for this OS and architecture,
+// e.g. leyzenctl-linux-amd64, skipping signatures and the checksum list.
+func PlatformAsset(assets []ReleaseAsset) (ReleaseAsset, error) {
+	platform := runtime.GOOS + "-" + runtime.GOARCH
+	for _, asset := range assets {
+		name := strings.ReplaceAll(asset.Name, "_", "-")
+		if strings.HasSuffix(name, ".sig") || asset.Name == checksumsAsset {
+			continue
+		}
+		if strings.HasPrefix(name, "leyzenctl") && strings.Contains(name, "-"+platform) {
+			return asset, nil
+		}
+	}
+	return ReleaseAsset{}, fmt.Errorf("the release has no leyzenctl binary for %s", platform)
+}
+
+// DownloadVerifiedAsset downloads asset and checks it against the release's checksums.txt.
+func DownloadVerifiedAsset(assets []ReleaseAsset, asset ReleaseAsset) ([]byte, error) {
+	var checksums ReleaseAsset
+	for _, a := range assets {
+		if a.Name == checksumsAsset {
+			checksums = a
+		}
+	}
+	if checksums.URL == "" {
+		return nil, fmt.Errorf("the release has no %s to verify the download against", checksumsAsset)
+	}
+
+	list, err := downloadAsset(checksums.URL)
+	if err != nil {
+		return nil, err
+	}
+	expected := ""
+	scanner := bufio.NewScanner(bytes.NewReader(list))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == asset.Name {
+			expected = strings.ToLower(fields[0])
+		}
+	}
+	if expected == "" {
+		return nil, fmt.Errorf("%s has no entry for %s", checksumsAsset, asset.Name)
+	}
+
+	data, err := downloadAsset(asset.URL)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", asset.Name, expected, actual)
+	}
+	return data, nil
+}
+
+func downloadAsset(url string) ([]byte, error) {
+	client := &http.Client{Timeout: downloadTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return data, nil
+}
+
+// CurrentExecutable returns the resolved path of the running binary and fails when it
+// cannot be replaced in place, i.e. when its directory is not writable.
+func CurrentExecutable() (string, error) {
+	path, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the running binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	probe, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".probe-*")
+	if err != nil {
+		return "", fmt.Errorf("%s is not writable (%w); rerun with sufficient permissions or reinstall manually", path, err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return path, nil
+}
+
+// ReplaceExecutable atomically swaps the binary at path for data, keeping its permissions.
+func ReplaceExecutable(path string, data []byte) error {
+	perm := os.FileMode(0o755)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := writeFileAtomic(path, data, perm); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"leyzenctl/internal/compose"
+)
+
+// OrchestratorContainerName is the container running the rotation service, before
+// ScopedName adds the compose project prefix.
+const OrchestratorContainerName = "orchestrator"
+
+// forceRotateScript asks the running orchestrator for a manual rotation through its
+// internal rotate endpoint, authenticated with INTERNAL_API_TOKEN. The token comes in
+// through the environment so it does not show up in the docker exec command line.
+const forceRotateScript = `
+import json, os, sys, urllib.request, urllib.error
+req = urllib.request.Request(
+    "http://localhost/orchestrator/api/internal/rotate",
+    data=b"{}",
+    headers={
+        "Content-Type": "application/json",
+        "Authorization": "Bearer " + os.environ.get("LEYZENCTL_INTERNAL_TOKEN", ""),
+    },
+)
+try:
+    with urllib.request.urlopen(req, timeout=120) as resp:
+        print(resp.read().decode())
+except urllib.error.HTTPError as e:
+    print(e.read().decode())
+`
+
+// OrchestratorEnabled reports whether ORCHESTRATOR_ENABLED is switched on in the env file.
+func OrchestratorEnabled(envFile string) (bool, error) {
+	resolvedEnv, err := ResolveEnvFilePath(envFile)
+	if err != nil {
+		return false, fmt.Errorf("resolve env file path: %w", err)
+	}
+	env, err := LoadEnvFile(resolvedEnv)
+	if err != nil {
+		return false, err
+	}
+	return isOrchestratorEnabled(env.Pairs()), nil
+}
+
+// VaultServiceNames returns the service name of the single vault container and the
+// prefix of the web replicas, as set by VAULT_APP_NAME and VAULT_WEB_PREFIX in envFile.
+// The defaults apply when the file cannot be read.
+func VaultServiceNames(envFile string) (app, webPrefix string) {
+	var pairs map[string]string
+	if resolvedEnv, err := ResolveEnvFilePath(envFile); err == nil {
+		if env, err := LoadEnvFile(resolvedEnv); err == nil {
+			pairs = env.Pairs()
+		}
+	}
+	return compose.VaultAppName(pairs), compose.VaultWebPrefix(pairs)
+}
+
+// ForceRotation triggers a manual rotation in the orchestrator and returns the name
+// of the container that became active.
+func ForceRotation(envFile string) (string, error) {
+	token, err := getInternalAPIToken(envFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to get internal API token: %w", err)
+	}
+	if token == "" {
+		return "", fmt.Errorf("INTERNAL_API_TOKEN is not set and SECRET_KEY is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeoutOr(apiTimeout))
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", "exec", "-e", "LEYZENCTL_INTERNAL_TOKEN", ScopedName(OrchestratorContainerName), "python3", "-c", forceRotateScript)
+	cmd.Env = append(os.Environ(), "LEYZENCTL_INTERNAL_TOKEN="+token)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("rotation request failed: %w - %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var resp struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &resp); err != nil {
+		return "", fmt.Errorf("unexpected orchestrator response: %s", strings.TrimSpace(stdout.String()))
+	}
+	if resp.Status != "ok" {
+		return "", fmt.Errorf("orchestrator refused rotation: %s", resp.Message)
+	}
+	// The orchestrator reports "<container> is now active."
+	return strings.TrimSuffix(resp.Message, " is now active."), nil
+}
+
+// WaitForHealthy polls a container until its healthcheck passes or the timeout expires.
+// Containers without a healthcheck are considered healthy once running.
+func WaitForHealthy(container string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	state := ""
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		out, err := exec.CommandContext(ctx, "docker", "inspect", "--format",
+			"{{if .State.Health}}{{.State.Health.Status}}{{else}}{{.State.Status}}{{end}}", container).Output()
+		cancel()
+		if err == nil {
+			state = strings.TrimSpace(string(out))
+			if state == "healthy" || state == "running" {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			if state == "" {
+				state = "unknown"
+			}
+			return fmt.Errorf("%s did not become healthy within %s (state: %s)", container, timeout, state)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
@@ -0,0 +1,262 @@
+package internal
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// EncryptSecretsKey is the env file switch that stores secret values encrypted.
+	EncryptSecretsKey = "LEYZEN_ENCRYPT_SECRETS"
+	// MasterKeyEnv holds the master key used to encrypt and decrypt secret values.
+	MasterKeyEnv = "LEYZENCTL_MASTER_KEY"
+	// MasterKeyFileEnv points to a file holding the master key, e.g. written by a keyring helper.
+	MasterKeyFileEnv = "LEYZENCTL_MASTER_KEY_FILE"
+
+	// encryptedValuePrefix marks values sealed with a scrypt-derived key; the random
+	// salt is stored in front of the nonce.
+	encryptedValuePrefix = "enc:v2:"
+	// legacyEncryptedValuePrefix marks values sealed with a plain SHA-256 of the master
+	// key. They are still read, and sealed again as v2 on the next write.
+	legacyEncryptedValuePrefix = "enc:v1:"
+
+	scryptSaltSize = 16
+)
+
+// scrypt cost parameters, the recommendation for interactive use.
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// IsEncryptedValue reports whether an env value was written encrypted.
+func IsEncryptedValue(value string) bool {
+	return strings.HasPrefix(value, encryptedValuePrefix) || strings.HasPrefix(value, legacyEncryptedValuePrefix)
+}
+
+// isEncryptableKey reports whether the value of key in envFile is stored encrypted when
+// LEYZEN_ENCRYPT_SECRETS is on: secret-looking names and variables typed as secret.
+func isEncryptableKey(envFile, key string) bool {
+	if key == EncryptSecretsKey {
+		return false
+	}
+	return IsSecretKey(key) || declaredEnvType(envFile, key) == "secret"
+}
+
+// encryptionEnabled reports whether the env file asks for encrypted secret values.
+func (f *EnvFile) encryptionEnabled() bool {
+	value, _ := f.Get(EncryptSecretsKey)
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "1", "yes", "on":
+		return true
+	}
+	return false
+}
+
+// masterKey returns the master key from LEYZENCTL_MASTER_KEY, or from the file named
+// by LEYZENCTL_MASTER_KEY_FILE. It is a passphrase: the AES-256 keys are derived from it.
+func masterKey() (string, error) {
+	secret := os.Getenv(MasterKeyEnv)
+	if secret == "" {
+		if path := os.Getenv(MasterKeyFileEnv); path != "" {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "", fmt.Errorf("read master key file: %w", err)
+			}
+			secret = strings.TrimSpace(string(data))
+		}
+	}
+	if secret == "" {
+		return "", fmt.Errorf("encrypted secrets need a master key: set %s or %s", MasterKeyEnv, MasterKeyFileEnv)
+	}
+	return secret, nil
+}
+
+var (
+	derivedKeysMu sync.Mutex
+	// derivedKeys caches scrypt results per master key and salt, since status polls
+	// decrypt the same values over and over.
+	derivedKeys = make(map[[sha256.Size]byte][]byte)
+	// writeSalt is shared by the values sealed in this process, so a write costs one
+	// derivation however many secrets it encrypts.
+	writeSalt []byte
+)
+
+// deriveKey stretches the master key into an AES-256 key with scrypt.
+func deriveKey(masterKey string, salt []byte) ([]byte, error) {
+	id := sha256.Sum256(append(append([]byte(masterKey), 0), salt...))
+	derivedKeysMu.Lock()
+	defer derivedKeysMu.Unlock()
+	if key, ok := derivedKeys[id]; ok {
+		return key, nil
+	}
+	key, err := scrypt.Key([]byte(masterKey), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, fmt.Errorf("derive key: %w", err)
+	}
+	derivedKeys[id] = key
+	return key, nil
+}
+
+func sealingSalt() ([]byte, error) {
+	derivedKeysMu.Lock()
+	defer derivedKeysMu.Unlock()
+	if writeSalt == nil {
+		salt := make([]byte, scryptSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		writeSalt = salt
+	}
+	return writeSalt, nil
+}
+
+func newSecretCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptValue seals plain with AES-GCM under a key derived from masterKey. The env key
+// is authenticated as additional data so a ciphertext cannot be moved to another variable.
+func encryptValue(masterKey, key, plain string) (string, error) {
+	salt, err := sealingSalt()
+	if err != nil {
+		return "", err
+	}
+	derived, err := deriveKey(masterKey, salt)
+	if err != nil {
+		return "", err
+	}
+	aead, err := newSecretCipher(derived)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(append(append([]byte{}, salt...), nonce...), nonce, []byte(plain), []byte(key))
+	return encryptedValuePrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func decryptValue(masterKey, key, value string) (string, error) {
+	var derived []byte
+	encoded, legacy := strings.CutPrefix(value, legacyEncryptedValuePrefix)
+	if legacy {
+		sum := sha256.Sum256([]byte(masterKey))
+		derived = sum[:]
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(encoded, encryptedValuePrefix))
+	if err != nil {
+		return "", errors.New("malformed encrypted value")
+	}
+	if !legacy {
+		if len(sealed) < scryptSaltSize {
+			return "", errors.New("malformed encrypted value")
+		}
+		if derived, err = deriveKey(masterKey, sealed[:scryptSaltSize]); err != nil {
+			return "", err
+		}
+		sealed = sealed[scryptSaltSize:]
+	}
+	aead, err := newSecretCipher(derived)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("malformed encrypted value")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil {
+		return "", errors.New("wrong master key or corrupted value")
+	}
+	return string(plain), nil
+}
+
+// DecryptedPairs returns the key/value pairs with encrypted values decrypted. It fails
+// rather than returning ciphertext that would otherwise end up in the compose stack.
+func (f *EnvFile) DecryptedPairs() (map[string]string, error) {
+	pairs := f.Pairs()
+	var key string
+	for name, value := range pairs {
+		if !IsEncryptedValue(value) {
+			continue
+		}
+		if key == "" {
+			var err error
+			if key, err = masterKey(); err != nil {
+				return nil, fmt.Errorf("decrypt %s: %w", name, err)
+			}
+		}
+		plain, err := decryptValue(key, name, value)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt %s: %w", name, err)
+		}
+		pairs[name] = plain
+	}
+	return pairs, nil
+}
+
+// EncryptedKeys returns the keys whose values are stored encrypted.
+func (f *EnvFile) EncryptedKeys() []string {
+	var keys []string
+	for _, entry := range f.Entries {
+		if entry.IsPair && IsEncryptedValue(entry.Value) {
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys
+}
+
+// sealEntries returns the entries to write: with encryption enabled, plaintext secret
+// values are encrypted. Values already in the current format are kept as is; legacy v1
+// values are sealed again as v2.
+func (f *EnvFile) sealEntries() ([]EnvEntry, error) {
+	if !f.encryptionEnabled() {
+		return f.Entries, nil
+	}
+	var key string
+	entries := make([]EnvEntry, len(f.Entries))
+	for i, entry := range f.Entries {
+		entries[i] = entry
+		upgrade := entry.IsPair && strings.HasPrefix(entry.Value, legacyEncryptedValuePrefix)
+		if !upgrade && (!entry.IsPair || entry.Value == "" || IsEncryptedValue(entry.Value) || !isEncryptableKey(f.Path, entry.Key)) {
+			continue
+		}
+		if key == "" {
+			var err error
+			if key, err = masterKey(); err != nil {
+				return nil, fmt.Errorf("encrypt %s: %w", entry.Key, err)
+			}
+		}
+		plain := entry.Value
+		if upgrade {
+			var err error
+			if plain, err = decryptValue(key, entry.Key, entry.Value); err != nil {
+				return nil, fmt.Errorf("decrypt %s: %w", entry.Key, err)
+			}
+		}
+		sealed, err := encryptValue(key, entry.Key, plain)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt %s: %w", entry.Key, err)
+		}
+		entries[i].Value = sealed
+		entries[i].Quote = 0
+	}
+	return entries, nil
+}
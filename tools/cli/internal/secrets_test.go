@@ -0,0 +1,156 @@
+package internal
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestEncryptValueRoundTrip(t *testing.T) {
+	key := "master"
+	for _, plain := range []string{"", "s3cr3t", "pass#word with spaces", strings.Repeat("x", 512)} {
+		sealed, err := encryptValue(key, "SECRET_KEY", plain)
+		if err != nil {
+			t.Fatalf("encryptValue: %v", err)
+		}
+		if !IsEncryptedValue(sealed) {
+			t.Errorf("encryptValue(%q) = %q, missing the %s prefix", plain, sealed, encryptedValuePrefix)
+		}
+		if plain != "" && strings.Contains(sealed, plain) {
+			t.Errorf("encryptValue(%q) leaks the plaintext: %q", plain, sealed)
+		}
+		got, err := decryptValue(key, "SECRET_KEY", sealed)
+		if err != nil {
+			t.Fatalf("decryptValue: %v", err)
+		}
+		if got != plain {
+			t.Errorf("round trip = %q, want %q", got, plain)
+		}
+	}
+}
+
+func TestDecryptValueRejectsWrongKeyOrVariable(t *testing.T) {
+	key, other := "master", "other"
+	sealed, err := encryptValue(key, "SECRET_KEY", "s3cr3t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decryptValue(other, "SECRET_KEY", sealed); err == nil {
+		t.Error("decryptValue accepted the wrong master key")
+	}
+	if _, err := decryptValue(key, "POSTGRES_PASSWORD", sealed); err == nil {
+		t.Error("decryptValue accepted a ciphertext moved to another variable")
+	}
+	if _, err := decryptValue(key, "SECRET_KEY", encryptedValuePrefix+"not-base64!"); err == nil {
+		t.Error("decryptValue accepted a malformed value")
+	}
+}
+
+func TestEnvFileEncryptsSecretsOnWrite(t *testing.T) {
+	t.Setenv(MasterKeyEnv, "master")
+	t.Setenv(MasterKeyFileEnv, "")
+	path := writeEnv(t, EncryptSecretsKey+"=true\nSECRET_KEY=s3cr3t\nHTTP_PORT=8080\n")
+
+	file, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Write(); err != nil {
+		t.Fatal(err)
+	}
+	written := readEnv(t, path)
+	if strings.Contains(written, "s3cr3t") || !strings.Contains(written, "SECRET_KEY="+encryptedValuePrefix) {
+		t.Fatalf("SECRET_KEY was not written encrypted:\n%s", written)
+	}
+	if !strings.Contains(written, "HTTP_PORT=8080") {
+		t.Errorf("HTTP_PORT should stay in plaintext:\n%s", written)
+	}
+
+	reloaded, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs, err := reloaded.DecryptedPairs()
+	if err != nil {
+		t.Fatalf("DecryptedPairs: %v", err)
+	}
+	if pairs["SECRET_KEY"] != "s3cr3t" {
+		t.Errorf("SECRET_KEY = %q after decryption, want s3cr3t", pairs["SECRET_KEY"])
+	}
+}
+
+// sealLegacyValue encrypts plain the way enc:v1 did, with a plain SHA-256 of the master key.
+func sealLegacyValue(t *testing.T, masterKey, key, plain string) string {
+	t.Helper()
+	sum := sha256.Sum256([]byte(masterKey))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	sealed := aead.Seal(nonce, nonce, []byte(plain), []byte(key))
+	return legacyEncryptedValuePrefix + base64.RawURLEncoding.EncodeToString(sealed)
+}
+
+func TestEncryptValueSaltsTheDerivedKey(t *testing.T) {
+	sealed, err := encryptValue("master", "SECRET_KEY", "s3cr3t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sealed, encryptedValuePrefix) {
+		t.Fatalf("encryptValue() = %q, want the %s format", sealed, encryptedValuePrefix)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(sealed, encryptedValuePrefix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The salt travels with the value, and the key is not a bare hash of the master key
+	unsalted := sha256.Sum256([]byte("master"))
+	block, _ := aes.NewCipher(unsalted[:])
+	aead, _ := cipher.NewGCM(block)
+	body := raw[scryptSaltSize:]
+	if _, err := aead.Open(nil, body[:aead.NonceSize()], body[aead.NonceSize():], []byte("SECRET_KEY")); err == nil {
+		t.Error("value opens with an unsalted SHA-256 of the master key")
+	}
+}
+
+func TestEnvFileUpgradesLegacyValuesOnWrite(t *testing.T) {
+	t.Setenv(MasterKeyEnv, "master")
+	t.Setenv(MasterKeyFileEnv, "")
+	legacy := sealLegacyValue(t, "master", "SECRET_KEY", "s3cr3t")
+	path := writeEnv(t, EncryptSecretsKey+"=true\nSECRET_KEY="+legacy+"\n")
+
+	file, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs, err := file.DecryptedPairs()
+	if err != nil {
+		t.Fatalf("DecryptedPairs: %v", err)
+	}
+	if pairs["SECRET_KEY"] != "s3cr3t" {
+		t.Fatalf("legacy SECRET_KEY = %q after decryption, want s3cr3t", pairs["SECRET_KEY"])
+	}
+
+	if err := file.Write(); err != nil {
+		t.Fatal(err)
+	}
+	written := readEnv(t, path)
+	if strings.Contains(written, legacyEncryptedValuePrefix) || !strings.Contains(written, "SECRET_KEY="+encryptedValuePrefix) {
+		t.Fatalf("SECRET_KEY was not sealed again as v2:\n%s", written)
+	}
+	reloaded, err := LoadEnvFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pairs, err := reloaded.DecryptedPairs(); err != nil || pairs["SECRET_KEY"] != "s3cr3t" {
+		t.Errorf("SECRET_KEY after upgrade = %q, %v, want s3cr3t", pairs["SECRET_KEY"], err)
+	}
+}
@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ProjectSettingsFileName is the per-project file holding remembered CLI choices.
+const ProjectSettingsFileName = ".leyzenctl"
+
+// ProjectSettings holds CLI preferences persisted in the repository root.
+type ProjectSettings struct {
+	EnvFile    string `json:"env_file,omitempty"`
+	LogModeRaw bool   `json:"log_mode_raw,omitempty"` // Dashboard shows raw logs
+	LogWrap    bool   `json:"log_wrap,omitempty"`     // Long log lines are wrapped
+	LastView   string `json:"last_view,omitempty"`    // Dashboard view restored on launch
+}
+
+func projectSettingsPath() (string, error) {
+	repoRoot, err := FindRepoRoot()
+	if err != nil {
+		return "", fmt.Errorf("find repository root: %w", err)
+	}
+	return filepath.Join(repoRoot, ProjectSettingsFileName), nil
+}
+
+// LoadProjectSettings reads the project settings file. A missing file yields empty settings.
+func LoadProjectSettings() (ProjectSettings, error) {
+	var settings ProjectSettings
+	path, err := projectSettingsPath()
+	if err != nil {
+		return settings, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return settings, nil
+		}
+		return settings, fmt.Errorf("read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return settings, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// SaveProjectSettings writes the project settings file, removing it when nothing is set.
+func SaveProjectSettings(settings ProjectSettings) error {
+	path, err := projectSettingsPath()
+	if err != nil {
+		return err
+	}
+	if settings == (ProjectSettings{}) {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove %s: %w", path, err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode project settings: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Agent periodically collects status and serves the latest result over HTTP.
+type Agent struct {
+	envFile  string
+	interval time.Duration
+	timeout  time.Duration
+
+	mu      sync.RWMutex
+	latest  *Result
+	lastErr error
+}
+
+// NewAgent returns an agent that collects every interval, probing with the given timeout.
+func NewAgent(envFile string, interval, timeout time.Duration) *Agent {
+	return &Agent{envFile: envFile, interval: interval, timeout: timeout}
+}
+
+// Run collects immediately, then on every tick until ctx is cancelled.
+func (a *Agent) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		a.refresh()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *Agent) refresh() {
+	res, err := Collect(a.envFile, a.timeout)
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.lastErr = err
+	if err == nil {
+		a.latest = &res
+	}
+}
+
+// Handler serves /status with the cached Result and /healthz for the agent itself.
+func (a *Agent) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		a.mu.RLock()
+		latest, lastErr := a.latest, a.lastErr
+		a.mu.RUnlock()
+
+		if latest == nil {
+			msg := "status not collected yet"
+			if lastErr != nil {
+				msg = lastErr.Error()
+			}
+			http.Error(w, msg, http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = RenderJSON(w, *latest)
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	return mux
+}
@@ -0,0 +1,49 @@
+package status
+
+import "fmt"
+
+// Thresholds configures when EvaluateAlerts reports a problem. Zero values disable a check.
+type Thresholds struct {
+	MaxStoragePercent float64
+	MaxMemoryPercent  float64
+	MaxCPUPercent     float64
+	MinReplicas       int
+}
+
+type Alert struct {
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+// EvaluateAlerts compares a collected result against the thresholds and returns every triggered alert.
+func EvaluateAlerts(r Result, t Thresholds) []Alert {
+	var alerts []Alert
+	if r.Summary.OverallStatus == "critical" {
+		alerts = append(alerts, Alert{Check: "overall", Message: "overall status is critical"})
+	}
+	if t.MaxStoragePercent > 0 && r.Storage.Data.Percent > t.MaxStoragePercent {
+		alerts = append(alerts, Alert{
+			Check:   "storage",
+			Message: fmt.Sprintf("storage usage %0.1f%% exceeds %0.1f%% (%s)", r.Storage.Data.Percent, t.MaxStoragePercent, r.Storage.Data.Path),
+		})
+	}
+	if t.MaxMemoryPercent > 0 && r.Performance.MemoryUsedPercent > t.MaxMemoryPercent {
+		alerts = append(alerts, Alert{
+			Check:   "memory",
+			Message: fmt.Sprintf("memory usage %0.1f%% exceeds %0.1f%%", r.Performance.MemoryUsedPercent, t.MaxMemoryPercent),
+		})
+	}
+	if t.MaxCPUPercent > 0 && r.Performance.CPULoadPercent > t.MaxCPUPercent {
+		alerts = append(alerts, Alert{
+			Check:   "cpu",
+			Message: fmt.Sprintf("CPU load %0.1f%% exceeds %0.1f%%", r.Performance.CPULoadPercent, t.MaxCPUPercent),
+		})
+	}
+	if t.MinReplicas > 0 && r.App.Status != StatusSkipped && r.App.ReplicasUp < t.MinReplicas {
+		alerts = append(alerts, Alert{
+			Check:   "replicas",
+			Message: fmt.Sprintf("%d of %d replicas up, expected at least %d", r.App.ReplicasUp, r.App.ReplicasTotal, t.MinReplicas),
+		})
+	}
+	return alerts
+}
@@ -1,6 +1,7 @@
 package status
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,15 +10,21 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"leyzenctl/internal"
+	"leyzenctl/internal/compose"
 	"leyzenctl/internal/version"
 	"syscall"
 )
 
+// statsTimeout bounds `docker stats --no-stream`, which samples for about a second.
+const statsTimeout = 5 * time.Second
+
 func parseBool(s string, def bool) bool {
 	t := strings.TrimSpace(strings.ToLower(s))
 	switch t {
@@ -29,9 +36,21 @@ func parseBool(s string, def bool) bool {
 	return def
 }
 
-func collectBackupsViaApp(container string, timeout time.Duration) (int, int, string, int64) {
+// appBackups is the backup summary reported by the in-container probe.
+type appBackups struct {
+	Local     int    `json:"local"`
+	S3        int    `json:"s3"`
+	Last      string `json:"last"`
+	S3Bytes   int64  `json:"s3_bytes"`
+	LastSize  int64  `json:"last_size"`
+	LastDurMs int64  `json:"last_duration_ms"`
+
+	Buckets []S3BucketStat `json:"buckets"`
+}
+
+func collectBackupsViaApp(container string, buckets []string, timeout time.Duration) appBackups {
 	script := `
-import json, os, time
+import json, os, sys, time
 from vault.app import create_app
 app = create_app()
 with app.app_context():
@@ -43,6 +62,14 @@ with app.app_context():
     s3_count = 0
     s3_bytes = 0
     last_ts = None
+    last_meta = None
+    buckets = []
+    def read_meta(path):
+        try:
+            with open(path) as fh:
+                return json.load(fh)
+        except Exception:
+            return None
     # Try service listing first
     try:
         if secret_key:
@@ -62,6 +89,7 @@ with app.app_context():
                         ts = datetime.fromisoformat(created.replace('Z','+00:00')).timestamp()
                         if (last_ts is None) or (ts>last_ts):
                             last_ts = ts
+                            last_meta = b.get("metadata") or None
                     except Exception:
                         pass
     except Exception:
@@ -77,56 +105,90 @@ with app.app_context():
                         ts=os.path.getmtime(p)
                         if (last_ts is None) or (ts>last_ts):
                             last_ts = ts
+                            last_meta = read_meta(p[:-len('.dump')]+'.metadata.json')
             except Exception:
                 pass
-    # S3 fallback scan via app config
+    # S3 fallback scan via app config, one pass per configured bucket
     try:
         if secret_key and ExternalStorageConfigService.is_enabled(secret_key, app):
             svc_ext = ExternalStorageService(secret_key, app)
             client = svc_ext._get_client()
             cfg = svc_ext._get_config()
-            bname = cfg.get('bucket_name') if cfg else None
-            if client and bname:
+            bnames = [x.strip() for x in str((cfg or {}).get('bucket_name') or '').split(',') if x.strip()]
+            for extra in sys.argv[1:]:
+                if extra not in bnames:
+                    bnames.append(extra)
+            if client:
                 paginator = client.get_paginator('list_objects_v2')
                 prefix='database-backups/'
-                for page in paginator.paginate(Bucket=bname, Prefix=prefix):
-                    for obj in page.get('Contents',[]):
-                        k=obj.get('Key','')
-                        if k.endswith('.dump') and k.split('/')[-1].startswith('backup_'):
-                            s3_count += 1
-                            s3_bytes += int(obj.get('Size',0) or 0)
-                            lm=obj.get('LastModified')
-                            if lm:
-                                ts = getattr(lm,'timestamp',None)
-                                if callable(ts):
-                                    ts = ts()
-                                elif isinstance(lm,str):
-                                    try:
-                                        from datetime import datetime
-                                        ts = datetime.fromisoformat(lm.replace('Z','+00:00')).timestamp()
-                                    except Exception:
-                                        ts = None
-                                if ts is not None and ((last_ts is None) or (ts>last_ts)):
-                                    last_ts = ts
+                for bname in bnames:
+                    stat = {"bucket": bname, "object_count": 0, "total_bytes": 0}
+                    bucket_ts = None
+                    try:
+                        for page in paginator.paginate(Bucket=bname, Prefix=prefix):
+                            for obj in page.get('Contents',[]):
+                                k=obj.get('Key','')
+                                if k.endswith('.dump') and k.split('/')[-1].startswith('backup_'):
+                                    size = int(obj.get('Size',0) or 0)
+                                    s3_count += 1
+                                    s3_bytes += size
+                                    stat["object_count"] += 1
+                                    stat["total_bytes"] += size
+                                    lm=obj.get('LastModified')
+                                    if lm:
+                                        ts = getattr(lm,'timestamp',None)
+                                        if callable(ts):
+                                            ts = ts()
+                                        elif isinstance(lm,str):
+                                            try:
+                                                from datetime import datetime
+                                                ts = datetime.fromisoformat(lm.replace('Z','+00:00')).timestamp()
+                                            except Exception:
+                                                ts = None
+                                        if ts is not None and ((bucket_ts is None) or (ts>bucket_ts)):
+                                            bucket_ts = ts
+                                        if ts is not None and ((last_ts is None) or (ts>last_ts)):
+                                            last_ts = ts
+                    except Exception:
+                        pass
+                    if bucket_ts:
+                        stat["last_backup_at"] = time.strftime('%Y-%m-%dT%H:%M:%SZ', time.gmtime(bucket_ts))
+                    buckets.append(stat)
     except Exception:
         pass
     last = time.strftime('%Y-%m-%dT%H:%M:%SZ', time.gmtime(last_ts)) if last_ts else None
-    print(json.dumps({"local":local_count,"s3":s3_count,"last":last,"s3_bytes":s3_bytes}))
+    # Size and duration of the latest backup from its metadata.json
+    last_size = 0
+    last_dur = 0
+    if isinstance(last_meta, dict):
+        try:
+            last_size = int((last_meta.get('file') or {}).get('size_bytes') or 0)
+        except Exception:
+            last_size = 0
+        try:
+            from datetime import datetime
+            # Only backups that recorded when they started and finished have a duration
+            timing = last_meta.get('timing') or {}
+            started = timing.get('started_at')
+            finished = timing.get('completed_at')
+            if started and finished:
+                t0 = datetime.fromisoformat(started.replace('Z','+00:00'))
+                t1 = datetime.fromisoformat(finished.replace('Z','+00:00'))
+                last_dur = max(0, int((t1 - t0).total_seconds() * 1000))
+        except Exception:
+            last_dur = 0
+    print(json.dumps({"local":local_count,"s3":s3_count,"last":last,"s3_bytes":s3_bytes,"last_size":last_size,"last_duration_ms":last_dur,"buckets":buckets}))
 `
-	out, err := runDockerExec(container, timeout, "python3", "-c", script)
+	var p appBackups
+	args := append([]string{"python3", "-c", script}, buckets...)
+	out, err := runDockerExec(container, timeout, args...)
 	if err != nil {
-		return 0, 0, "", 0
-	}
-	var p struct {
-		Local   int    `json:"local"`
-		S3      int    `json:"s3"`
-		Last    string `json:"last"`
-		S3Bytes int64  `json:"s3_bytes"`
+		return p
 	}
 	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &p); err != nil {
-		return 0, 0, "", 0
+		return appBackups{}
 	}
-	return p.Local, p.S3, p.Last, p.S3Bytes
+	return p
 }
 func parseInt(s string, def int) int {
 	if v, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
@@ -240,7 +302,13 @@ func getServiceStatusMap(envFile string) map[string]string {
 	return out
 }
 
+// Collect probes every section of the stack.
 func Collect(envFile string, timeout time.Duration) (Result, error) {
+	return CollectSections(envFile, timeout, SectionFilter{})
+}
+
+// CollectSections probes the sections enabled by filter; the others get StatusSkipped.
+func CollectSections(envFile string, timeout time.Duration, filter SectionFilter) (Result, error) {
 	var res Result
 	env, err := internal.LoadAllEnvVariables(envFile)
 	if err != nil {
@@ -258,64 +326,72 @@ func Collect(envFile string, timeout time.Duration) (Result, error) {
 	if enableHTTPS {
 		res.PortStats = append(res.PortStats, PortStat{Name: "HTTPS", Port: httpsPort, Protocol: "tcp"})
 	}
-	res.Performance.CPULoadPercent = cpuLoadPercent()
-	res.Performance.MemoryUsedPercent = memUsedPercent()
-
-	var endpoints []string
-	webContainers, _ := resolveWebContainersForStatus(env)
-	for range webContainers {
-		endpoints = append(endpoints, fmt.Sprintf("http://localhost:%d/healthz", httpPort))
-		break
-	}
-
-	appUp := 0
-	var appEndpoints []Endpoint
-	for _, url := range endpoints {
-		lat, code, err := httpGet(url, timeout)
-		ep := Endpoint{Name: "vault_web", Address: url, LatencyMs: lat}
-		if err == nil && code == 200 {
-			ep.Reachable = true
-			ep.Status = "ok"
-			appUp++
-		} else {
-			ep.Reachable = false
-			ep.Status = "degraded"
-			if err != nil {
-				ep.Message = err.Error()
-			}
-		}
-		appEndpoints = append(appEndpoints, ep)
+	// /proc describes this machine, which is not the docker host when it is remote
+	if internal.DockerIsRemote() {
+		res.Performance.LocalOnly = true
+	} else {
+		res.Performance.CPULoadPercent = cpuLoadPercent()
+		res.Performance.MemoryUsedPercent = memUsedPercent()
 	}
-	res.App.Endpoints = appEndpoints
-	res.App.ReplicasTotal = len(webContainers)
-	res.App.ReplicasUp = appUp
-	res.App.Status = "ok"
-	if appUp == 0 {
-		res.App.Status = "critical"
-		res.App.Message = "all replicas down"
+
+	var serviceStatuses map[string]string
+	if filter.Enabled(SectionApp) || filter.Enabled(SectionDB) {
+		serviceStatuses = getServiceStatusMap(envFile)
 	}
 
-	latHTTP, upHTTP := dial(fmt.Sprintf("localhost:%d", httpPort), time.Duration(timeout))
-	res.Infra.HAProxyHTTPUp = upHTTP
-	res.Infra.LatencyMs = latHTTP
-	if enableHTTPS {
-		latHTTPS, upHTTPS := dial(fmt.Sprintf("localhost:%d", httpsPort), time.Duration(timeout))
-		res.Infra.HAProxyHTTPSUp = upHTTPS
-		if latHTTPS > 0 {
-			res.Infra.LatencyMs = latHTTPS
+	if filter.Enabled(SectionApp) {
+		webContainers, _ := resolveWebContainersForStatus(env)
+		appEndpoints := probeReplicas(webContainers, serviceStatuses, timeout)
+		if parseBool(env["ORCHESTRATOR_ENABLED"], false) {
+			markStandbyReplicas(appEndpoints, serviceStatuses)
 		}
+		res.App.Endpoints = appEndpoints
+		res.App.ReplicasTotal = len(webContainers)
+		for _, ep := range appEndpoints {
+			if ep.Reachable {
+				res.App.ReplicasUp++
+			}
+		}
+		res.App.Status, res.App.Message = appHealth(appEndpoints)
+	} else {
+		res.App.Status = StatusSkipped
 	}
-	res.Infra.Status = "ok"
-	if !upHTTP {
-		res.Infra.Status = "degraded"
+
+	if filter.Enabled(SectionInfra) {
+		latHTTP, upHTTP := dial(fmt.Sprintf("localhost:%d", httpPort), time.Duration(timeout))
+		res.Infra.HAProxyHTTPUp = upHTTP
+		res.Infra.LatencyMs = latHTTP
+		if enableHTTPS {
+			latHTTPS, upHTTPS := dial(fmt.Sprintf("localhost:%d", httpsPort), time.Duration(timeout))
+			res.Infra.HAProxyHTTPSUp = upHTTPS
+			if latHTTPS > 0 {
+				res.Infra.LatencyMs = latHTTPS
+			}
+		}
+		res.Infra.Status = "ok"
+		if !upHTTP {
+			res.Infra.Status = "degraded"
+		}
+
+		if parseBool(env["ORCHESTRATOR_ENABLED"], false) {
+			orch := collectOrchestrator(httpPort, timeout)
+			res.Orchestrator = &orch
+		}
+	} else {
+		res.Infra.Status = StatusSkipped
 	}
 
 	s3Endpoint := strings.TrimSpace(env["VAULT_S3_ENDPOINT_URL"])
 	s3Bucket := strings.TrimSpace(env["VAULT_S3_BUCKET_NAME"])
+	s3Buckets := splitBucketNames(s3Bucket)
 	useSSL := parseBool(env["VAULT_S3_USE_SSL"], true)
 	res.S3.Endpoint = s3Endpoint
 	res.S3.Bucket = s3Bucket
-	if s3Endpoint != "" {
+	if !filter.Enabled(SectionS3) {
+		res.S3.Status = StatusSkipped
+		// Spare the fallback backup scan the bucket listing as well
+		s3Buckets = nil
+	} else if s3Endpoint != "" {
 		host, port := parseHostPortFromURL(s3Endpoint, useSSL)
 		latS3, upS3 := dial(net.JoinHostPort(host, port), time.Duration(timeout))
 		res.S3.LatencyMs = latS3
@@ -330,109 +406,142 @@ func Collect(envFile string, timeout time.Duration) (Result, error) {
 		res.S3.Message = "not configured"
 	}
 
-	dbHost := strings.TrimSpace(env["POSTGRES_HOST"])
-	if dbHost == "" {
-		dbHost = "postgres"
-	}
-	dbPort := parseInt(env["POSTGRES_PORT"], 5432)
-	// Prefer docker health status; fall back to TCP dial if not available
-	serviceStatuses := getServiceStatusMap(envFile)
-	var dbServiceStatus string
-	if s, ok := serviceStatuses["postgres"]; ok {
-		dbServiceStatus = s
-	} else {
-		// try best-effort lookup
-		for k, v := range serviceStatuses {
-			if strings.Contains(strings.ToLower(k), "postgres") {
-				dbServiceStatus = v
-				break
+	if filter.Enabled(SectionDB) {
+		dbHost := strings.TrimSpace(env["POSTGRES_HOST"])
+		if dbHost == "" {
+			dbHost = "postgres"
+		}
+		dbPort := parseInt(env["POSTGRES_PORT"], 5432)
+		// Prefer docker health status; fall back to TCP dial if not available
+		var dbServiceStatus string
+		if s, ok := serviceStatuses["postgres"]; ok {
+			dbServiceStatus = s
+		} else {
+			// try best-effort lookup
+			for k, v := range serviceStatuses {
+				if strings.Contains(strings.ToLower(k), "postgres") {
+					dbServiceStatus = v
+					break
+				}
 			}
 		}
-	}
-	if dbServiceStatus != "" {
-		lower := strings.ToLower(dbServiceStatus)
-		isUp := strings.Contains(lower, "up")
-		isHealthy := strings.Contains(lower, "healthy")
-		res.DB.Reachable = isUp || isHealthy
-		if res.DB.Reachable {
-			res.DB.Status = "ok"
+		if dbServiceStatus != "" {
+			lower := strings.ToLower(dbServiceStatus)
+			isUp := strings.Contains(lower, "up")
+			isHealthy := strings.Contains(lower, "healthy")
+			res.DB.Reachable = isUp || isHealthy
+			if res.DB.Reachable {
+				res.DB.Status = "ok"
+			} else {
+				res.DB.Status = "degraded"
+				res.DB.Message = dbServiceStatus
+			}
+			// leave latency empty for docker-based check
 		} else {
-			res.DB.Status = "degraded"
-			res.DB.Message = dbServiceStatus
+			latDB, upDB := dial(net.JoinHostPort(dbHost, strconv.Itoa(dbPort)), time.Duration(timeout))
+			res.DB.LatencyMs = latDB
+			res.DB.Reachable = upDB
+			res.DB.Status = "ok"
+			if !upDB {
+				res.DB.Status = "degraded"
+				res.DB.Message = "unreachable"
+			}
 		}
-		// leave latency empty for docker-based check
 	} else {
-		latDB, upDB := dial(net.JoinHostPort(dbHost, strconv.Itoa(dbPort)), time.Duration(timeout))
-		res.DB.LatencyMs = latDB
-		res.DB.Reachable = upDB
-		res.DB.Status = "ok"
-		if !upDB {
-			res.DB.Status = "degraded"
-			res.DB.Message = "unreachable"
-		}
+		res.DB.Status = StatusSkipped
 	}
 
-	repoRoot, _ := internal.FindRepoRoot()
-	dataPath := repoRoot
-	st, err := fsStats(dataPath)
-	if err == nil {
-		res.Storage.Data = st
-		res.Storage.Status = "ok"
+	if filter.Enabled(SectionStorage) {
+		repoRoot, _ := internal.FindRepoRoot()
+		dataPath := repoRoot
+		st, err := fsStats(dataPath)
+		if err == nil {
+			res.Storage.Data = st
+			res.Storage.Status = "ok"
+		} else {
+			res.Storage.Status = "unknown"
+			res.Storage.Message = "filesystem stats unavailable"
+		}
 	} else {
-		res.Storage.Status = "unknown"
-		res.Storage.Message = "filesystem stats unavailable"
+		res.Storage.Status = StatusSkipped
 	}
 
-	res.Backup.Status = "unknown"
-	res.Backup.Message = "metadata unavailable"
+	if filter.Enabled(SectionBackup) {
+		res.Backup.Status = "unknown"
+		res.Backup.Message = "metadata unavailable"
+	} else {
+		res.Backup.Status = StatusSkipped
+	}
 
-	// Container storage and backups via docker exec (vault_app preferred)
-	container := detectVaultContainer(envFile)
+	// Container storage and backups via docker exec (the app container preferred)
+	var container string
+	if filter.Enabled(SectionStorage) || filter.Enabled(SectionBackup) {
+		container = detectVaultContainer(envFile, env)
+	}
 	if container != "" {
-		if cs, ok := collectContainerStorage(container, timeout); ok {
-			res.Storage.Data = cs
-			res.Storage.Status = "ok"
-		}
-		// Prefer app-aware listing for accurate summary
-		lc2, sc2, last2, s3b2 := collectBackupsViaApp(container, timeout)
-		if lc2 > 0 || sc2 > 0 {
-			res.Backup.LocalCount = lc2
-			res.Backup.S3Count = sc2
-			if last2 != "" {
-				res.Backup.LastSuccessAt = last2
-			}
-			if sc2 > 0 {
-				res.S3.ObjectCount = sc2
-			}
-			if s3b2 > 0 {
-				res.S3.TotalBytes = s3b2
-			}
-		} else {
-			// Fallback to raw scans
-			lc, lts := collectLocalBackups(container, timeout)
-			res.Backup.LocalCount = lc
-			if lts != "" {
-				res.Backup.LastSuccessAt = lts
-			}
-			sc, s3bytes, s3last := collectS3Backups(container, timeout)
-			res.Backup.S3Count = sc
-			if sc > 0 {
-				res.S3.ObjectCount = sc
+		if filter.Enabled(SectionStorage) {
+			if cs, ok := collectContainerStorage(container, timeout); ok {
+				res.Storage.Data = cs
+				res.Storage.Status = "ok"
 			}
-			if s3bytes > 0 {
-				res.S3.TotalBytes = s3bytes
-			}
-			if s3last != "" {
-				res.S3.LastBackupAt = s3last
-				if res.Backup.LastSuccessAt == "" {
-					res.Backup.LastSuccessAt = s3last
+		}
+		if filter.Enabled(SectionBackup) {
+			// Prefer app-aware listing for accurate summary
+			ab := collectBackupsViaApp(container, s3Buckets, timeout)
+			if ab.Local > 0 || ab.S3 > 0 {
+				res.Backup.LocalCount = ab.Local
+				res.Backup.S3Count = ab.S3
+				if ab.Last != "" {
+					res.Backup.LastSuccessAt = ab.Last
+				}
+				res.Backup.LastArtifactSizeB = ab.LastSize
+				res.Backup.LastDurationMs = ab.LastDurMs
+				if ab.S3 > 0 {
+					res.S3.ObjectCount = ab.S3
+				}
+				if ab.S3Bytes > 0 {
+					res.S3.TotalBytes = ab.S3Bytes
+				}
+				if len(ab.Buckets) > 0 {
+					res.S3.Buckets = ab.Buckets
+					_, _, res.S3.LastBackupAt = sumBucketStats(ab.Buckets)
+				}
+			} else {
+				// Fallback to raw scans
+				lc, lts := collectLocalBackups(container, timeout)
+				res.Backup.LocalCount = lc
+				if lts != "" {
+					res.Backup.LastSuccessAt = lts
+				}
+				bucketStats := collectS3Backups(container, s3Buckets, timeout)
+				if len(bucketStats) > 0 {
+					res.S3.Buckets = bucketStats
+				}
+				sc, s3bytes, s3last := sumBucketStats(bucketStats)
+				res.Backup.S3Count = sc
+				if sc > 0 {
+					res.S3.ObjectCount = sc
+				}
+				if s3bytes > 0 {
+					res.S3.TotalBytes = s3bytes
+				}
+				if s3last != "" {
+					res.S3.LastBackupAt = s3last
+					if res.Backup.LastSuccessAt == "" {
+						res.Backup.LastSuccessAt = s3last
+					}
 				}
 			}
+			if res.Backup.LocalCount > 0 || res.Backup.S3Count > 0 {
+				res.Backup.Status = "ok"
+				res.Backup.Message = ""
+			}
 		}
-		if res.Backup.LocalCount > 0 || res.Backup.S3Count > 0 {
-			res.Backup.Status = "ok"
-			res.Backup.Message = ""
-		}
+	}
+
+	if !filter.Enabled(SectionS3) {
+		// The backup listing fills S3 counts on its own; a skipped section stays empty
+		res.S3 = S3Section{Endpoint: s3Endpoint, Bucket: s3Bucket, Status: StatusSkipped}
 	}
 
 	overall := "ok"
@@ -441,7 +550,10 @@ func Collect(envFile string, timeout time.Duration) (Result, error) {
 		overall = "critical"
 		critical = append(critical, "app")
 	}
-	if res.DB.Status == "degraded" && overall != "critical" {
+	if (res.DB.Status == "degraded" || res.App.Status == "degraded") && overall != "critical" {
+		overall = "degraded"
+	}
+	if res.Orchestrator != nil && res.Orchestrator.Status != "ok" && overall != "critical" {
 		overall = "degraded"
 	}
 	res.Summary.OverallStatus = overall
@@ -458,6 +570,8 @@ func Collect(envFile string, timeout time.Duration) (Result, error) {
 		}
 	}
 
+	res.Resources = CollectContainerResources(envFile, statsTimeout)
+
 	return res, nil
 }
 
@@ -465,8 +579,152 @@ func MarshalJSON(res Result) ([]byte, error) {
 	return json.MarshalIndent(res, "", "  ")
 }
 
+// MarshalJSONLine encodes a Result as one compact JSON line ending in a newline,
+// as used by the history file and NDJSON streams.
+func MarshalJSONLine(res Result) ([]byte, error) {
+	b, err := MarshalJSON(res)
+	if err != nil {
+		return nil, err
+	}
+	var line bytes.Buffer
+	if err := json.Compact(&line, b); err != nil {
+		return nil, err
+	}
+	line.WriteByte('\n')
+	return line.Bytes(), nil
+}
+
+// collectOrchestrator probes the orchestrator through HAProxy and the docker-proxy
+// through its own healthcheck, since the proxy is only reachable on the control network.
+func collectOrchestrator(httpPort int, timeout time.Duration) OrchestratorSection {
+	var sec OrchestratorSection
+
+	url := fmt.Sprintf("http://localhost:%d/orchestrator/healthz", httpPort)
+	lat, code, err := httpGet(url, timeout)
+	sec.Orchestrator = Endpoint{Name: "orchestrator", Address: url, LatencyMs: lat}
+	if err == nil && code == 200 {
+		sec.Orchestrator.Reachable = true
+		sec.Orchestrator.Status = "ok"
+	} else {
+		sec.Orchestrator.Status = "degraded"
+		if err != nil {
+			sec.Orchestrator.Message = err.Error()
+		} else {
+			sec.Orchestrator.Message = fmt.Sprintf("HTTP %d", code)
+		}
+	}
+
+	start := time.Now()
+	_, err = runDockerExec(internal.ScopedName("docker-proxy"), timeout, "curl", "-fsS", "http://localhost:2375/healthz")
+	sec.DockerProxy = Endpoint{
+		Name:      "docker-proxy",
+		Address:   "docker-proxy:2375",
+		LatencyMs: int64(time.Since(start).Milliseconds()),
+	}
+	if err == nil {
+		sec.DockerProxy.Reachable = true
+		sec.DockerProxy.Status = "ok"
+	} else {
+		sec.DockerProxy.Status = "degraded"
+		sec.DockerProxy.Message = err.Error()
+	}
+
+	sec.Status = "ok"
+	switch {
+	case !sec.Orchestrator.Reachable && !sec.DockerProxy.Reachable:
+		sec.Status = "degraded"
+		sec.Message = "orchestrator and docker-proxy unreachable"
+	case !sec.Orchestrator.Reachable:
+		sec.Status = "degraded"
+		sec.Message = "orchestrator unreachable"
+	case !sec.DockerProxy.Reachable:
+		sec.Status = "degraded"
+		sec.Message = "docker-proxy unreachable"
+	}
+	return sec
+}
+
+// probeReplicas checks every replica concurrently, so the whole probe takes about one
+// timeout regardless of the replica count. Endpoints keep the order of names.
+func probeReplicas(names []string, serviceStatuses map[string]string, timeout time.Duration) []Endpoint {
+	endpoints := make([]Endpoint, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			endpoints[i] = probeReplica(name, serviceStatuses[name], timeout)
+		}(i, name)
+	}
+	wg.Wait()
+	return endpoints
+}
+
+// probeReplica runs the vault healthcheck inside one replica and reports it as an endpoint.
+// dockerStatus is the compose status of the container, kept for context.
+func probeReplica(name, dockerStatus string, timeout time.Duration) Endpoint {
+	ep := Endpoint{
+		Name:    name,
+		Address: fmt.Sprintf("http://%s:%d/healthz", name, compose.VaultWebPort),
+	}
+	if dockerStatus != "" {
+		ep.Extra = map[string]string{"docker_status": dockerStatus}
+	}
+	start := time.Now()
+	_, err := runDockerExec(internal.ScopedName(name), timeout, "python3", "/app/infra/vault/healthcheck.py")
+	ep.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		ep.Status = "degraded"
+		ep.Message = "healthcheck failed"
+		if dockerStatus == "" {
+			ep.Message = "container not running"
+		}
+		return ep
+	}
+	ep.Reachable = true
+	ep.Status = "ok"
+	return ep
+}
+
+// endpointStandby marks a replica the orchestrator keeps stopped until it rotates to it.
+const endpointStandby = "standby"
+
+// markStandbyReplicas flags the replicas whose container is not running. The orchestrator
+// keeps a single replica active and stops the others by design.
+func markStandbyReplicas(endpoints []Endpoint, serviceStatuses map[string]string) {
+	for i, ep := range endpoints {
+		if ep.Reachable || strings.HasPrefix(serviceStatuses[ep.Name], "Up") {
+			continue
+		}
+		endpoints[i].Status = endpointStandby
+		endpoints[i].Message = "stopped standby"
+	}
+}
+
+// appHealth summarizes the replica endpoints. Standby replicas do not count as down, so
+// the app is critical only when no running replica passes its healthcheck.
+func appHealth(endpoints []Endpoint) (string, string) {
+	expected, up := 0, 0
+	for _, ep := range endpoints {
+		if ep.Status == endpointStandby {
+			continue
+		}
+		expected++
+		if ep.Reachable {
+			up++
+		}
+	}
+	switch {
+	case up == 0:
+		return "critical", "all replicas down"
+	case up < expected:
+		return "degraded", fmt.Sprintf("%d of %d replicas down", expected-up, expected)
+	}
+	return "ok", ""
+}
+
 func resolveWebContainersForStatus(env map[string]string) ([]string, string) {
-	if strings.TrimSpace(env["ORCHESTRATOR_ENABLED"]) == "true" {
+	if parseBool(env["ORCHESTRATOR_ENABLED"], false) {
 		val := strings.TrimSpace(env["ORCH_WEB_CONTAINERS"])
 		if val != "" {
 			names := strings.Split(val, ",")
@@ -481,14 +739,20 @@ func resolveWebContainersForStatus(env map[string]string) ([]string, string) {
 				return out, val
 			}
 		}
+		// Mirror the generator: default 3 replicas, never fewer than the minimum
 		replicas := parseInt(env["WEB_REPLICAS"], 3)
+		if replicas < compose.VaultMinReplicas {
+			replicas = compose.VaultMinReplicas
+		}
+		prefix := compose.VaultWebPrefix(env)
 		var names []string
 		for i := 0; i < replicas; i++ {
-			names = append(names, fmt.Sprintf("vault_web%d", i+1))
+			names = append(names, fmt.Sprintf("%s%d", prefix, i+1))
 		}
 		return names, strings.Join(names, ",")
 	}
-	return []string{"vault_app"}, "vault_app"
+	app := compose.VaultAppName(env)
+	return []string{app}, app
 }
 
 func runDockerExec(container string, timeout time.Duration, args ...string) (string, error) {
@@ -502,14 +766,32 @@ func runDockerExec(container string, timeout time.Duration, args ...string) (str
 	return string(out), nil
 }
 
-func detectVaultContainer(envFile string) string {
-	m := getServiceStatusMap(envFile)
-	if _, ok := m["vault_app"]; ok {
-		return "vault_app"
+// detectVaultContainer returns the container name of the vault app or of its first web
+// replica, as named by env. Containers are matched by service so a compose project
+// prefix is ignored.
+func detectVaultContainer(envFile string, env map[string]string) string {
+	psOutput, err := internal.DockerComposePS(envFile, "--format", "{{.Service}}\t{{.Name}}")
+	if err != nil || psOutput == "" {
+		return ""
 	}
-	for name := range m {
-		if strings.HasPrefix(name, "vault_web") {
-			return name
+	containers := make(map[string]string)
+	var services []string
+	for _, line := range strings.Split(psOutput, "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) >= 2 {
+			service := strings.TrimSpace(parts[0])
+			containers[service] = strings.TrimSpace(parts[1])
+			services = append(services, service)
+		}
+	}
+	if name, ok := containers[compose.VaultAppName(env)]; ok {
+		return name
+	}
+	prefix := compose.VaultWebPrefix(env)
+	sort.Strings(services)
+	for _, service := range services {
+		if strings.HasPrefix(service, prefix) {
+			return containers[service]
 		}
 	}
 	return ""
@@ -533,6 +815,74 @@ func collectContainerStorage(container string, timeout time.Duration) (StorageSt
 	return StorageStats{Path: "/data", UsedBytes: p.Used, TotalBytes: p.Total, Percent: percent(p.Used, p.Total)}, true
 }
 
+// CollectContainerResources samples CPU and memory usage for the project's running containers,
+// sorted by name. It returns an empty slice when docker stats is unavailable or times out.
+func CollectContainerResources(envFile string, timeout time.Duration) []ContainerResource {
+	out := []ContainerResource{}
+	names, err := internal.DockerComposePS(envFile, "--filter", "status=running", "--format", "{{.Name}}")
+	if err != nil || strings.TrimSpace(names) == "" {
+		return out
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	args := append([]string{"stats", "--no-stream", "--format", "{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}"}, strings.Fields(names)...)
+	raw, err := exec.CommandContext(ctx, "docker", args...).Output()
+	if err != nil {
+		return out
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(raw)), "\n") {
+		parts := strings.Split(line, "\t")
+		if len(parts) < 4 {
+			continue
+		}
+		r := ContainerResource{
+			Name:       strings.TrimSpace(parts[0]),
+			CPUPercent: parsePercent(parts[1]),
+			MemPercent: parsePercent(parts[3]),
+		}
+		if usage := strings.SplitN(parts[2], "/", 2); len(usage) == 2 {
+			r.MemUsageBytes = parseByteSize(usage[0])
+			r.MemLimitBytes = parseByteSize(usage[1])
+		}
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func parsePercent(s string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// parseByteSize parses docker's human sizes such as "12.5MiB" or "1.2GB".
+func parseByteSize(s string) int64 {
+	s = strings.TrimSpace(s)
+	units := []struct {
+		suffix string
+		mult   float64
+	}{
+		{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+		{"kB", 1e3}, {"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+		{"B", 1},
+	}
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			v, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), 64)
+			if err != nil {
+				return 0
+			}
+			return int64(v * u.mult)
+		}
+	}
+	return 0
+}
+
 func percent(used, total int64) float64 {
 	if total <= 0 {
 		return 0
@@ -570,43 +920,96 @@ print(json.dumps({'count':cnt,'latest':latest}))`)
 	return p.Count, p.Latest
 }
 
-func collectS3Backups(container string, timeout time.Duration) (int, int64, string) {
-	out, err := runDockerExec(container, timeout, "python3", "-c",
-		"import json,os; import boto3; from botocore.config import Config;"+
-			"e=os.environ.get('VAULT_S3_ENDPOINT_URL'); b=os.environ.get('VAULT_S3_BUCKET_NAME');"+
-			"ak=os.environ.get('VAULT_S3_ACCESS_KEY_ID'); sk=os.environ.get('VAULT_S3_SECRET_ACCESS_KEY');"+
-			"rg=os.environ.get('VAULT_S3_REGION','auto'); use_ssl=os.environ.get('VAULT_S3_USE_SSL','true').lower() in ('1','true','yes');"+
-			"if not e or not b or not ak or not sk: print(json.dumps({'count':0,'bytes':0,'latest':None})); exit(0);"+
-			"cfg={'region_name':rg};"+
-			"if e: cfg['endpoint_url']=e;"+
-			"client=boto3.client('s3',**cfg,aws_access_key_id=ak,aws_secret_access_key=sk,config=Config(s3={'addressing_style':'path'}));"+
-			"prefix='database-backups/';"+
-			"paginator=client.get_paginator('list_objects_v2');"+
-			"names=set(); bytes=0; latest=None;"+
-			"for page in paginator.paginate(Bucket=b, Prefix=prefix):"+
-			"  for obj in page.get('Contents',[]):"+
-			"    k=obj['Key'];"+
-			"    fname=k.split('/')[-1];"+
-			"    if fname.startswith('backup_') and (fname.endswith('.dump') or fname.endswith('.metadata.json')):"+
-			"      base=fname.split('.')[0];"+
-			"      names.add(base);"+
-			"      if fname.endswith('.dump'): bytes+=obj.get('Size',0);"+
-			"      lm=obj.get('LastModified');"+
-			"      if lm and (latest is None or lm>latest): latest=lm;"+
-			"print(json.dumps({'count':len(names),'bytes':bytes,'latest':latest.isoformat() if latest else None}))")
+// collectS3Backups lists backup objects in every bucket and reports one entry per bucket.
+func collectS3Backups(container string, buckets []string, timeout time.Duration) []S3BucketStat {
+	script := `
+import json, os, sys
+import boto3
+from botocore.config import Config
+e=os.environ.get('VAULT_S3_ENDPOINT_URL')
+ak=os.environ.get('VAULT_S3_ACCESS_KEY_ID')
+sk=os.environ.get('VAULT_S3_SECRET_ACCESS_KEY')
+rg=os.environ.get('VAULT_S3_REGION','auto')
+names=sys.argv[1:] or [x.strip() for x in os.environ.get('VAULT_S3_BUCKET_NAME','').split(',') if x.strip()]
+out=[]
+if e and ak and sk:
+    cfg={'region_name':rg}
+    if e:
+        cfg['endpoint_url']=e
+    client=boto3.client('s3',**cfg,aws_access_key_id=ak,aws_secret_access_key=sk,config=Config(s3={'addressing_style':'path'}))
+    prefix='database-backups/'
+    paginator=client.get_paginator('list_objects_v2')
+    for b in names:
+        bases=set(); size=0; latest=None
+        try:
+            for page in paginator.paginate(Bucket=b, Prefix=prefix):
+                for obj in page.get('Contents',[]):
+                    fname=obj['Key'].split('/')[-1]
+                    if fname.startswith('backup_') and (fname.endswith('.dump') or fname.endswith('.metadata.json')):
+                        bases.add(fname.split('.')[0])
+                        if fname.endswith('.dump'):
+                            size+=obj.get('Size',0)
+                        lm=obj.get('LastModified')
+                        if lm and (latest is None or lm>latest):
+                            latest=lm
+        except Exception:
+            pass
+        stat={'bucket':b,'object_count':len(bases),'total_bytes':size}
+        if latest:
+            stat['last_backup_at']=latest.isoformat()
+        out.append(stat)
+print(json.dumps(out))
+`
+	args := append([]string{"python3", "-c", script}, buckets...)
+	out, err := runDockerExec(container, timeout, args...)
 	if err != nil {
-		return 0, 0, ""
+		return nil
 	}
-	var p struct {
-		Count  int    `json:"count"`
-		Bytes  int64  `json:"bytes"`
-		Latest string `json:"latest"`
+	var stats []S3BucketStat
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &stats); err != nil {
+		return nil
 	}
-	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), &p); err != nil {
-		return 0, 0, ""
+	return stats
+}
+
+// splitBucketNames parses a comma-separated VAULT_S3_BUCKET_NAME value.
+func splitBucketNames(raw string) []string {
+	var names []string
+	for _, part := range strings.Split(raw, ",") {
+		if name := strings.TrimSpace(part); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// sumBucketStats aggregates per-bucket counts and returns the most recent backup time.
+func sumBucketStats(stats []S3BucketStat) (int, int64, string) {
+	var count int
+	var bytes int64
+	var latest string
+	var latestTime time.Time
+	for _, st := range stats {
+		count += st.ObjectCount
+		bytes += st.TotalBytes
+		if st.LastBackupAt == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, st.LastBackupAt)
+		if err != nil {
+			if latest == "" {
+				latest = st.LastBackupAt
+			}
+			continue
+		}
+		if latestTime.IsZero() || t.After(latestTime) {
+			latestTime = t
+			latest = st.LastBackupAt
+		}
 	}
-	return p.Count, p.Bytes, p.Latest
+	return count, bytes, latest
 }
+
 func parseHostPortFromURL(raw string, ssl bool) (string, string) {
 	u := strings.TrimSpace(raw)
 	u = strings.TrimPrefix(u, "http://")
@@ -0,0 +1,61 @@
+package status
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveWebContainersForStatusUsesCustomNames(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want []string
+	}{
+		{map[string]string{"ORCHESTRATOR_ENABLED": "true", "WEB_REPLICAS": "2"}, []string{"vault_web1", "vault_web2"}},
+		{map[string]string{"ORCHESTRATOR_ENABLED": "true", "WEB_REPLICAS": "2", "VAULT_WEB_PREFIX": "web"}, []string{"web1", "web2"}},
+		{map[string]string{"ORCHESTRATOR_ENABLED": "false"}, []string{"vault_app"}},
+		{map[string]string{"ORCHESTRATOR_ENABLED": "false", "VAULT_APP_NAME": "vault"}, []string{"vault"}},
+	}
+	for _, tt := range tests {
+		if got, _ := resolveWebContainersForStatus(tt.env); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("resolveWebContainersForStatus(%v) = %v, want %v", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestAppHealthIgnoresStoppedStandbys(t *testing.T) {
+	statuses := map[string]string{"vault_web1": "Up 5 minutes (healthy)", "vault_web2": "Exited (0) 4 minutes ago", "vault_web3": "Up 1 minute"}
+	probed := func() []Endpoint {
+		return []Endpoint{
+			{Name: "vault_web1", Reachable: true, Status: "ok"},
+			{Name: "vault_web2", Status: "degraded"},
+			{Name: "vault_web3", Status: "degraded"},
+		}
+	}
+
+	// Without the orchestrator every replica is expected to serve
+	if status, message := appHealth(probed()); status != "degraded" || message != "2 of 3 replicas down" {
+		t.Errorf("appHealth() = %q, %q, want degraded with 2 of 3 down", status, message)
+	}
+
+	// A stopped standby is not down, but a running replica failing its healthcheck is
+	endpoints := probed()
+	markStandbyReplicas(endpoints, statuses)
+	if endpoints[1].Status != endpointStandby || endpoints[2].Status == endpointStandby {
+		t.Fatalf("markStandbyReplicas() = %+v, want only vault_web2 as standby", endpoints)
+	}
+	if status, message := appHealth(endpoints); status != "degraded" || message != "1 of 2 replicas down" {
+		t.Errorf("appHealth() = %q, %q, want degraded with 1 of 2 down", status, message)
+	}
+
+	delete(statuses, "vault_web3")
+	endpoints = probed()
+	markStandbyReplicas(endpoints, statuses)
+	if status, _ := appHealth(endpoints); status != "ok" {
+		t.Errorf("appHealth() = %q with one active replica and stopped standbys, want ok", status)
+	}
+
+	endpoints[0].Reachable = false
+	if status, _ := appHealth(endpoints); status != "critical" {
+		t.Errorf("appHealth() = %q with no reachable replica, want critical", status)
+	}
+}
@@ -0,0 +1,76 @@
+package status
+
+import (
+	"sort"
+	"strings"
+
+	"leyzenctl/internal"
+)
+
+// AnnotateDependencies adds the depends_on relationships of docker-generated.yml to the
+// container rows, marking a service as blocked when one of its dependencies is not met.
+func AnnotateDependencies(res *Result) error {
+	manifest, err := internal.LoadGeneratedManifest()
+	if err != nil {
+		return err
+	}
+
+	statuses := make(map[string]string, len(res.Containers))
+	for _, c := range res.Containers {
+		statuses[c.Name] = c.Status
+	}
+
+	for i, c := range res.Containers {
+		service, ok := manifest.Services[c.Name]
+		if !ok || len(service.DependsOn) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(service.DependsOn))
+		for name := range service.DependsOn {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		deps := make([]Dependency, 0, len(names))
+		blocked := false
+		for _, name := range names {
+			condition := service.DependsOn[name].Condition
+			if condition == "" {
+				condition = "service_started"
+			}
+			satisfied := dependencyMet(condition, statuses[name])
+			blocked = blocked || !satisfied
+			deps = append(deps, Dependency{Service: name, Condition: condition, Satisfied: satisfied})
+		}
+		res.Containers[i].DependsOn = deps
+		res.Containers[i].Blocked = blocked
+	}
+	return nil
+}
+
+// dependencyMet reports whether a compose status fulfils a depends_on condition.
+func dependencyMet(condition, status string) bool {
+	lower := strings.ToLower(status)
+	running := strings.HasPrefix(lower, "up")
+	switch condition {
+	case "service_healthy":
+		// A service without a healthcheck shows no health state and counts once running
+		return running && !strings.Contains(lower, "unhealthy") && !strings.Contains(lower, "health: starting")
+	case "service_completed_successfully":
+		return strings.HasPrefix(lower, "exited (0)")
+	default:
+		return running
+	}
+}
+
+// dependencyLabel describes a dependency condition in a few words.
+func dependencyLabel(condition string) string {
+	switch condition {
+	case "service_healthy":
+		return "healthy"
+	case "service_completed_successfully":
+		return "completed"
+	default:
+		return "started"
+	}
+}
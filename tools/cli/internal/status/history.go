@@ -0,0 +1,42 @@
+package status
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultHistoryMaxBytes is the size at which the history file is rotated.
+const DefaultHistoryMaxBytes int64 = 10 * 1024 * 1024
+
+// AppendHistory appends the result as a single JSON line to path.
+// When the file grows beyond maxBytes it is rotated to path+".1" first,
+// replacing any previous rotation. A maxBytes of zero disables rotation.
+func AppendHistory(path string, res Result, maxBytes int64) error {
+	line, err := MarshalJSONLine(res)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create history dir: %w", err)
+	}
+
+	if maxBytes > 0 {
+		if st, err := os.Stat(path); err == nil && st.Size()+int64(len(line)) > maxBytes {
+			if err := os.Rename(path, path+".1"); err != nil {
+				return fmt.Errorf("rotate history file: %w", err)
+			}
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("open history file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(line); err != nil {
+		return fmt.Errorf("write history file: %w", err)
+	}
+	return nil
+}
@@ -29,15 +29,24 @@ type AppSection struct {
 }
 
 type S3Section struct {
-	Endpoint     string `json:"endpoint"`
+	Endpoint     string         `json:"endpoint"`
+	Bucket       string         `json:"bucket"`
+	Reachable    bool           `json:"reachable"`
+	LatencyMs    int64          `json:"latency_ms"`
+	ObjectCount  int            `json:"object_count"`
+	TotalBytes   int64          `json:"total_bytes"`
+	LastBackupAt string         `json:"last_backup_at,omitempty"`
+	Buckets      []S3BucketStat `json:"buckets,omitempty"`
+	Status       string         `json:"status"`
+	Message      string         `json:"message,omitempty"`
+}
+
+// S3BucketStat is the backup summary of a single bucket when several are configured.
+type S3BucketStat struct {
 	Bucket       string `json:"bucket"`
-	Reachable    bool   `json:"reachable"`
-	LatencyMs    int64  `json:"latency_ms"`
 	ObjectCount  int    `json:"object_count"`
 	TotalBytes   int64  `json:"total_bytes"`
 	LastBackupAt string `json:"last_backup_at,omitempty"`
-	Status       string `json:"status"`
-	Message      string `json:"message,omitempty"`
 }
 
 type BackupSection struct {
@@ -65,6 +74,13 @@ type InfraSection struct {
 	Message        string `json:"message,omitempty"`
 }
 
+type OrchestratorSection struct {
+	Orchestrator Endpoint `json:"orchestrator"`
+	DockerProxy  Endpoint `json:"docker_proxy"`
+	Status       string   `json:"status"`
+	Message      string   `json:"message,omitempty"`
+}
+
 type StorageStats struct {
 	Path       string  `json:"path"`
 	UsedBytes  int64   `json:"used_bytes"`
@@ -87,23 +103,45 @@ type PortStat struct {
 type PerformanceStats struct {
 	CPULoadPercent    float64 `json:"cpu_load_percent"`
 	MemoryUsedPercent float64 `json:"memory_used_percent"`
+	// LocalOnly is set when docker targets a remote host; the stats above are then not collected
+	LocalOnly bool `json:"local_only,omitempty"`
+}
+
+type ContainerResource struct {
+	Name          string  `json:"name"`
+	CPUPercent    float64 `json:"cpu_percent"`
+	MemUsageBytes int64   `json:"mem_usage_bytes"`
+	MemLimitBytes int64   `json:"mem_limit_bytes"`
+	MemPercent    float64 `json:"mem_percent"`
 }
 
 type Result struct {
-	Summary     Summary           `json:"summary"`
-	App         AppSection        `json:"app"`
-	S3          S3Section         `json:"s3"`
-	Backup      BackupSection     `json:"backup"`
-	DB          DBSection         `json:"db"`
-	Infra       InfraSection      `json:"infra"`
-	Storage     StorageSection    `json:"storage"`
-	Containers  []ContainerStatus `json:"containers"`
-	PortStats   []PortStat        `json:"port_stats,omitempty"`
-	Performance PerformanceStats  `json:"performance,omitempty"`
+	Summary      Summary              `json:"summary"`
+	App          AppSection           `json:"app"`
+	S3           S3Section            `json:"s3"`
+	Backup       BackupSection        `json:"backup"`
+	DB           DBSection            `json:"db"`
+	Infra        InfraSection         `json:"infra"`
+	Orchestrator *OrchestratorSection `json:"orchestrator,omitempty"`
+	Storage      StorageSection       `json:"storage"`
+	Containers   []ContainerStatus    `json:"containers"`
+	Resources    []ContainerResource  `json:"container_resources"`
+	PortStats    []PortStat           `json:"port_stats,omitempty"`
+	Performance  PerformanceStats     `json:"performance,omitempty"`
 }
 
 type ContainerStatus struct {
 	Name   string `json:"name"`
 	Status string `json:"status"`
 	Age    string `json:"age"`
+	// DependsOn and Blocked are only filled by AnnotateDependencies (status --deps)
+	DependsOn []Dependency `json:"depends_on,omitempty"`
+	Blocked   bool         `json:"blocked,omitempty"`
+}
+
+// Dependency is a depends_on entry of the generated manifest and whether it is met.
+type Dependency struct {
+	Service   string `json:"service"`
+	Condition string `json:"condition"`
+	Satisfied bool   `json:"satisfied"`
 }
@@ -148,13 +148,15 @@ func grid3(w io.Writer, width int, colsA, colsB, colsC []string) {
 func badge(s string) string {
 	switch s {
 	case "ok":
-		return color.HiGreenString("OK")
+		return color.HiGreenString("✓ OK")
 	case "degraded":
-		return color.HiYellowString("DEGRADED")
+		return color.HiYellowString("! DEGRADED")
 	case "critical":
-		return color.HiRedString("CRITICAL")
+		return color.HiRedString("✗ CRITICAL")
+	case StatusSkipped:
+		return color.HiBlackString("- SKIPPED")
 	default:
-		return color.HiBlueString(strings.ToUpper(s))
+		return color.HiBlueString("? " + strings.ToUpper(s))
 	}
 }
 
@@ -167,6 +169,11 @@ func RenderHuman(w io.Writer, r Result) {
 	rowHeader(w, width, left, center, right)
 	fmt.Fprintln(w, "├"+strings.Repeat("─", width-2)+"┤")
 
+	if skipped := skippedSections(r); len(skipped) > 0 {
+		row(w, width, color.HiBlackString("Skipped: "+strings.Join(skipped, ", ")))
+		fmt.Fprintln(w, "├"+strings.Repeat("─", width-2)+"┤")
+	}
+
 	row(w, width, color.HiCyanString("Containers"))
 	if len(r.Containers) > 0 {
 		header := "  " +
@@ -175,23 +182,86 @@ func RenderHuman(w io.Writer, r Result) {
 			internal.PadRightVisible("Age", 12)
 		row(w, width, header)
 		for _, c := range r.Containers {
+			name := c.Name
+			if c.Blocked {
+				name = color.HiYellowString(c.Name)
+			}
 			line := "  " +
-				internal.PadRightVisible(c.Name, 18) + " " +
+				internal.PadRightVisible(name, 18) + " " +
 				internal.PadRightVisible(internal.FormatStatusColor(c.Status), 28) + " " +
 				internal.PadRightVisible(c.Age, 12)
 			row(w, width, line)
+			renderDependencies(w, width, c)
 		}
 	} else {
 		row(w, width, "  No services found")
 	}
 	fmt.Fprintln(w, "├"+strings.Repeat("─", width-2)+"┤")
 
+	if len(r.Resources) > 0 {
+		row(w, width, color.HiCyanString("Container Resources"))
+		header := "  " +
+			internal.PadRightVisible("Container", 18) + " " +
+			internal.PadRightVisible("CPU", 8) + " " +
+			internal.PadRightVisible("Memory", 24) + " " +
+			internal.PadRightVisible("Mem %", 8)
+		row(w, width, header)
+		for _, c := range r.Resources {
+			line := "  " +
+				internal.PadRightVisible(c.Name, 18) + " " +
+				internal.PadRightVisible(fmt.Sprintf("%0.1f%%", c.CPUPercent), 8) + " " +
+				internal.PadRightVisible(fmt.Sprintf("%s / %s", humanMiB(c.MemUsageBytes), humanMiB(c.MemLimitBytes)), 24) + " " +
+				internal.PadRightVisible(fmt.Sprintf("%0.1f%%", c.MemPercent), 8)
+			row(w, width, line)
+		}
+		fmt.Fprintln(w, "├"+strings.Repeat("─", width-2)+"┤")
+	}
+
+	if r.Orchestrator != nil {
+		row(w, width, color.HiCyanString("Orchestration")+"  "+badge(r.Orchestrator.Status))
+		for _, ep := range []Endpoint{r.Orchestrator.Orchestrator, r.Orchestrator.DockerProxy} {
+			state := color.HiGreenString("✓ reachable")
+			if !ep.Reachable {
+				state = color.HiRedString("✗ unreachable")
+			}
+			row(w, width, "  "+internal.PadRightVisible(ep.Name, 18)+" "+
+				internal.PadRightVisible(state, 14)+" "+fmt.Sprintf("%dms", ep.LatencyMs))
+		}
+		fmt.Fprintln(w, "├"+strings.Repeat("─", width-2)+"┤")
+	}
+
+	if r.Backup.LastSuccessAt != "" || r.Backup.LastArtifactSizeB > 0 {
+		line := color.HiCyanString("Backups") + fmt.Sprintf("  %d local, %d S3", r.Backup.LocalCount, r.Backup.S3Count)
+		row(w, width, line)
+		if r.Backup.LastSuccessAt != "" {
+			row(w, width, "  Last success: "+r.Backup.LastSuccessAt)
+		}
+		if r.Backup.LastArtifactSizeB > 0 {
+			last := "  Last backup: " + humanMB(r.Backup.LastArtifactSizeB)
+			if r.Backup.LastDurationMs > 0 {
+				last += fmt.Sprintf(" in %0.1fs", float64(r.Backup.LastDurationMs)/1000.0)
+			}
+			row(w, width, last)
+		}
+		if len(r.S3.Buckets) > 1 {
+			for _, b := range r.S3.Buckets {
+				row(w, width, "  "+internal.PadRightVisible(b.Bucket, 24)+" "+
+					internal.PadRightVisible(fmt.Sprintf("%d objects", b.ObjectCount), 14)+" "+humanMB(b.TotalBytes))
+			}
+		}
+		fmt.Fprintln(w, "├"+strings.Repeat("─", width-2)+"┤")
+	}
+
 	var proxyLines []string
 	proxyLines = append(proxyLines, color.HiCyanString("Proxy"))
-	proxyLines = append(proxyLines, fmt.Sprintf("HTTP %t", r.Infra.HAProxyHTTPUp))
-	proxyLines = append(proxyLines, fmt.Sprintf("HTTPS %t", r.Infra.HAProxyHTTPSUp))
-	if r.Infra.LatencyMs > 0 {
-		proxyLines = append(proxyLines, fmt.Sprintf("Latency %dms", r.Infra.LatencyMs))
+	if r.Infra.Status == StatusSkipped {
+		proxyLines = append(proxyLines, badge(StatusSkipped))
+	} else {
+		proxyLines = append(proxyLines, fmt.Sprintf("HTTP %t", r.Infra.HAProxyHTTPUp))
+		proxyLines = append(proxyLines, fmt.Sprintf("HTTPS %t", r.Infra.HAProxyHTTPSUp))
+		if r.Infra.LatencyMs > 0 {
+			proxyLines = append(proxyLines, fmt.Sprintf("Latency %dms", r.Infra.LatencyMs))
+		}
 	}
 
 	var portsLines []string
@@ -206,14 +276,38 @@ func RenderHuman(w io.Writer, r Result) {
 
 	var perfLines []string
 	perfLines = append(perfLines, color.HiCyanString("Server Performance"))
-	perfLines = append(perfLines, fmt.Sprintf("CPU %0.1f%%", r.Performance.CPULoadPercent))
-	perfLines = append(perfLines, fmt.Sprintf("Memory %0.1f%%", r.Performance.MemoryUsedPercent))
+	if r.Performance.LocalOnly {
+		perfLines = append(perfLines, "CPU n/a (local only)")
+		perfLines = append(perfLines, "Memory n/a (local only)")
+	} else {
+		perfLines = append(perfLines, fmt.Sprintf("CPU %0.1f%%", r.Performance.CPULoadPercent))
+		perfLines = append(perfLines, fmt.Sprintf("Memory %0.1f%%", r.Performance.MemoryUsedPercent))
+	}
 
 	grid3(w, width, proxyLines, portsLines, perfLines)
 
 	fmt.Fprintln(w, "└"+strings.Repeat("─", width-2)+"┘")
 }
 
+// skippedSections names the sections of r that were filtered out of the collection.
+func skippedSections(r Result) []string {
+	statuses := map[string]string{
+		SectionApp:     r.App.Status,
+		SectionDB:      r.DB.Status,
+		SectionS3:      r.S3.Status,
+		SectionBackup:  r.Backup.Status,
+		SectionInfra:   r.Infra.Status,
+		SectionStorage: r.Storage.Status,
+	}
+	var skipped []string
+	for _, section := range Sections {
+		if statuses[section] == StatusSkipped {
+			skipped = append(skipped, section)
+		}
+	}
+	return skipped
+}
+
 func RenderJSON(w io.Writer, r Result) error {
 	b, err := json.MarshalIndent(r, "", "  ")
 	if err != nil {
@@ -231,6 +325,18 @@ func humanGB(b int64) string {
 	return fmt.Sprintf("%0.2f GB", gb)
 }
 
+func humanMB(b int64) string {
+	mb := float64(b) / 1_000_000.0
+	if mb >= 10 {
+		return fmt.Sprintf("%0.0f MB", mb)
+	}
+	return fmt.Sprintf("%0.1f MB", mb)
+}
+
+func humanMiB(b int64) string {
+	return fmt.Sprintf("%0.1f MiB", float64(b)/(1<<20))
+}
+
 func shortenURL(u string) string {
 	s := strings.TrimSpace(u)
 	s = strings.TrimPrefix(s, "https://")
@@ -240,3 +346,26 @@ func shortenURL(u string) string {
 	}
 	return s
 }
+
+// renderDependencies lists what a container waits on, under its row. Blocked services
+// are shown in the warning color.
+func renderDependencies(w io.Writer, width int, c ContainerStatus) {
+	if len(c.DependsOn) == 0 {
+		return
+	}
+	parts := make([]string, 0, len(c.DependsOn))
+	for _, d := range c.DependsOn {
+		mark := color.HiGreenString("✓")
+		if !d.Satisfied {
+			mark = color.HiRedString("✗")
+		}
+		parts = append(parts, fmt.Sprintf("%s %s (%s)", mark, d.Service, dependencyLabel(d.Condition)))
+	}
+	prefix := "waits on"
+	if c.Blocked {
+		prefix = color.HiYellowString("blocked by")
+	}
+	for _, line := range wrapSingle(prefix+" "+strings.Join(parts, ", "), width-10) {
+		row(w, width, "    ↳ "+line)
+	}
+}
@@ -0,0 +1,77 @@
+package status
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sections of a snapshot that status --only and --exclude select. The orchestrator
+// probes belong to infra.
+const (
+	SectionApp     = "app"
+	SectionDB      = "db"
+	SectionS3      = "s3"
+	SectionBackup  = "backup"
+	SectionInfra   = "infra"
+	SectionStorage = "storage"
+)
+
+// StatusSkipped is the status of a section that was filtered out, as opposed to
+// "unknown" for a section that was probed without a conclusive answer.
+const StatusSkipped = "skipped"
+
+// Sections lists every selectable section in display order.
+var Sections = []string{SectionApp, SectionDB, SectionS3, SectionBackup, SectionInfra, SectionStorage}
+
+// SectionFilter selects the sections Collect probes. The zero value probes all of them.
+type SectionFilter struct {
+	skipped map[string]bool
+}
+
+// NewSectionFilter keeps the sections of only (all when empty), minus those of exclude.
+func NewSectionFilter(only, exclude []string) (SectionFilter, error) {
+	for _, name := range append(append([]string(nil), only...), exclude...) {
+		if !isSection(name) {
+			return SectionFilter{}, fmt.Errorf("unknown status section %q (expected one of %s)", name, strings.Join(Sections, ", "))
+		}
+	}
+
+	filter := SectionFilter{skipped: make(map[string]bool)}
+	if len(only) > 0 {
+		for _, section := range Sections {
+			filter.skipped[section] = true
+		}
+		for _, name := range only {
+			delete(filter.skipped, name)
+		}
+	}
+	for _, name := range exclude {
+		filter.skipped[name] = true
+	}
+	return filter, nil
+}
+
+// Enabled reports whether the section is probed.
+func (f SectionFilter) Enabled(section string) bool {
+	return !f.skipped[section]
+}
+
+// Skipped returns the filtered-out sections in display order.
+func (f SectionFilter) Skipped() []string {
+	var skipped []string
+	for _, section := range Sections {
+		if f.skipped[section] {
+			skipped = append(skipped, section)
+		}
+	}
+	return skipped
+}
+
+func isSection(name string) bool {
+	for _, section := range Sections {
+		if section == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package status
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	webhookAttempts = 3
+	webhookTimeout  = 10 * time.Second
+	webhookBackoff  = 2 * time.Second
+)
+
+// ParseWebhookHeaders turns "Name: Value" flags into an http.Header.
+func ParseWebhookHeaders(values []string) (http.Header, error) {
+	headers := http.Header{}
+	for _, value := range values {
+		name, content, ok := strings.Cut(value, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid webhook header %q (expected 'Name: Value')", value)
+		}
+		headers.Add(name, strings.TrimSpace(content))
+	}
+	return headers, nil
+}
+
+// PostWebhook sends the result as JSON to url. Network errors, 429 and 5xx responses are
+// retried a few times; any other non-2xx response fails immediately. Cancelling ctx
+// aborts the request in flight and the wait between attempts.
+func PostWebhook(ctx context.Context, url string, headers http.Header, res Result) error {
+	body, err := MarshalJSON(res)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: webhookTimeout}
+	var lastErr error
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		if attempt > 1 {
+			timer := time.NewTimer(webhookBackoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("webhook delivery cancelled: %w", lastErr)
+			case <-timer.C:
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("invalid webhook URL: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for name, values := range headers {
+			req.Header[name] = values
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("webhook delivery cancelled: %w", err)
+			}
+			lastErr = err
+			continue
+		}
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		resp.Body.Close()
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+
+		lastErr = fmt.Errorf("webhook returned %s", resp.Status)
+		if text := strings.TrimSpace(string(snippet)); text != "" {
+			lastErr = fmt.Errorf("webhook returned %s: %s", resp.Status, text)
+		}
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			return lastErr
+		}
+	}
+	return fmt.Errorf("failed to post status after %d attempts: %w", webhookAttempts, lastErr)
+}
@@ -0,0 +1,35 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPostWebhookStopsRetryingWhenCancelled(t *testing.T) {
+	var calls atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		// Cancel while the first attempt fails, so the backoff is where it must stop
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	err := PostWebhook(ctx, server.URL, nil, Result{})
+	if err == nil {
+		t.Fatal("PostWebhook succeeded against a failing endpoint")
+	}
+	if elapsed := time.Since(start); elapsed >= webhookBackoff {
+		t.Errorf("PostWebhook took %s, want it to return without waiting out the %s backoff", elapsed, webhookBackoff)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("endpoint called %d times, want 1", got)
+	}
+}
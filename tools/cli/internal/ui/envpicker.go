@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/filepicker"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"leyzenctl/internal"
+)
+
+// openEnvPicker shows a file picker rooted at the repository to choose another env file.
+func (m *Model) openEnvPicker() tea.Cmd {
+	repoRoot, err := internal.FindRepoRoot()
+	if err != nil {
+		m.successMessage = fmt.Sprintf("[ERROR] %v", err)
+		return tea.Tick(successMessageDuration, func(time.Time) tea.Msg { return successTimeoutMsg{} })
+	}
+
+	picker := filepicker.New()
+	picker.CurrentDirectory = repoRoot
+	// Env files are usually dotfiles (.env, .env.staging)
+	picker.ShowHidden = true
+	picker.ShowPermissions = false
+	picker.AutoHeight = false
+	picker.Height = m.envPickerHeight()
+	picker.Styles.Cursor = m.theme.Accent
+	picker.Styles.Selected = m.theme.Accent
+
+	m.envPicker = picker
+	m.envPickerErr = ""
+	m.viewState = ViewEnvPicker
+	return m.envPicker.Init()
+}
+
+func (m *Model) envPickerHeight() int {
+	return max(m.height-10, 6)
+}
+
+func (m *Model) handleEnvPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Esc is also the picker's "parent directory" key; left and backspace still do that
+	if msg.String() == "esc" {
+		m.switchToDashboard()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.envPicker, cmd = m.envPicker.Update(msg)
+	if didSelect, path := m.envPicker.DidSelectFile(msg); didSelect {
+		return m, m.switchEnvFile(path)
+	}
+	return m, cmd
+}
+
+// switchEnvFile makes path the active env file. Whatever was tied to the previous file
+// (statuses, action output, loaded configuration) is dropped and reloaded for the new one.
+func (m *Model) switchEnvFile(path string) tea.Cmd {
+	resolved, err := internal.ResolveEnvFilePath(path)
+	if err != nil {
+		m.envPickerErr = err.Error()
+		return nil
+	}
+	setupIssues, err := internal.PreflightEnv(resolved)
+	if err != nil {
+		m.envPickerErr = err.Error()
+		return nil
+	}
+
+	if m.actionStream != nil {
+		// Let an action of the previous env file finish in the background
+		go func(stream <-chan actionProgressMsg) {
+			for range stream {
+			}
+		}(m.actionStream)
+	}
+	m.switchToDashboard()
+	m.logsBuffer = nil
+	m.logsRaw = nil
+	m.confirmAction = ActionNone
+	m.pendingRefresh = false
+
+	m.envFile = resolved
+	m.runner.envFile = resolved
+	// Actions, status polls and logs must target the stack of the new env file
+	internal.SetComposeProjectName(internal.ResolveComposeProjectName(resolved, m.projectNameFlag))
+	m.setupIssues = setupIssues
+	m.statuses = nil
+	m.statusCursor = 0
+	m.transitions = nil
+	m.restartCounts = map[string]int{}
+	m.crashLoops = map[string]time.Time{}
+	m.crashLogs = map[string][]string{}
+	m.configPairs = nil
+	// Reload the configuration without opening the wizard, unless the new file needs fixing
+	m.configReload = len(setupIssues) == 0
+
+	m.successMessage = "Switched to " + resolved
+	return tea.Batch(
+		fetchConfigListCmd(resolved),
+		fetchStatusesCmd(resolved),
+		tea.Tick(successMessageDuration, func(time.Time) tea.Msg { return successTimeoutMsg{} }),
+	)
+}
+
+func (m *Model) renderEnvPickerView() string {
+	var rows []string
+	rows = append(rows, m.theme.Accent.Render("Select an env file"))
+	rows = append(rows, m.theme.Subtitle.Render(m.envPicker.CurrentDirectory), "")
+	rows = append(rows, strings.TrimRight(m.envPicker.View(), "\n"))
+	if m.envPickerErr != "" {
+		rows = append(rows, "", m.theme.ErrorStatus.Render("Cannot use this file: "+m.envPickerErr))
+	}
+
+	var parts []string
+	parts = append(parts, m.renderHeader())
+	if m.quitConfirm {
+		parts = append(parts, m.renderQuitConfirmation())
+	}
+	parts = append(parts, m.theme.Pane.Render(strings.Join(rows, "\n")))
+	parts = append(parts, m.renderFooter(contextEnvPicker))
+
+	layout := lipgloss.JoinVertical(lipgloss.Left, parts...)
+	return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, layout)
+}
@@ -0,0 +1,119 @@
+package ui
+
+import "strings"
+
+// Footer contexts, one per view that renders a footer.
+const (
+	contextDashboard          = "dashboard"
+	contextLogs               = "logs"
+	contextAction             = "action"
+	contextConfig             = "config"
+	contextWizard             = "wizard"
+	contextContainerSelection = "container-selection"
+	contextContainerDetail    = "container-detail"
+	contextEnvPicker          = "env-picker"
+	contextHelp               = "help"
+	contextTop                = "top"
+)
+
+// keyBinding describes one key as handled by Update. The keymap below is the single
+// source for the footer hints and the help cheatsheet, so add new keys here as well.
+type keyBinding struct {
+	Key      string   // Label shown to the user
+	Short    string   // Footer hint; empty keeps the key out of the footer
+	Help     string   // Cheatsheet description
+	Group    string   // Cheatsheet section
+	Contexts []string // Footers that show the hint
+}
+
+var keymap = []keyBinding{
+	{Key: "Ctrl+C", Short: "Quit", Help: "Quit the dashboard (press twice to confirm)", Group: "Global",
+		Contexts: []string{contextDashboard, contextLogs, contextAction, contextConfig, contextWizard, contextContainerSelection, contextContainerDetail, contextEnvPicker, contextHelp}},
+
+	{Key: "a", Short: "Start", Help: "Start the stack (docker compose up)", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "r", Short: "Restart", Help: "Restart the stack", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "Ctrl+R", Short: "Restart service", Help: "Restart only the selected service", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "s", Short: "Stop", Help: "Stop the stack (stopping everything asks for confirmation)", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "b", Short: "Rebuild", Help: "Rebuild configuration", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "c", Short: "Config", Help: "View configuration", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "w", Short: "Wizard", Help: "Run the configuration wizard", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "l", Short: "Logs", Help: "View logs", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "e", Short: "Env file", Help: "Switch to another env file", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "t", Short: "Events", Help: "Toggle recent status changes", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "p", Short: "Pause", Help: "Pause or resume the automatic status refresh", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "f", Short: "Refresh", Help: "Refresh the service statuses now, even while paused", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "x", Short: "Crash details", Help: "Show exit code and last log lines of crash-looping services", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "↑/↓", Help: "Select a service in the status panel", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "Enter", Short: "Inspect", Help: "Show details of the selected container", Group: "Dashboard", Contexts: []string{contextDashboard}},
+	{Key: "?", Short: "Help", Help: "Toggle this cheatsheet", Group: "Dashboard", Contexts: []string{contextDashboard}},
+
+	{Key: "Esc", Short: "Back", Help: "Return to the dashboard", Group: "Logs & actions", Contexts: []string{contextLogs, contextConfig}},
+	{Key: "Esc", Short: "Back (wait for completion)", Help: "Return to the dashboard once the action finishes", Group: "Logs & actions", Contexts: []string{contextAction}},
+	{Key: "↑/↓", Short: "Scroll", Help: "Scroll logs, action output or configuration", Group: "Logs & actions", Contexts: []string{contextLogs, contextAction, contextConfig}},
+	{Key: "Space", Short: "Scroll lock", Help: "Stop following new lines while reading (press again to resume)", Group: "Logs & actions", Contexts: []string{contextLogs, contextAction}},
+	{Key: "w", Short: "Wrap", Help: "Wrap long log lines instead of cutting them at the edge", Group: "Logs & actions", Contexts: []string{contextLogs, contextAction}},
+	{Key: "v", Short: "Raw view", Help: "Toggle raw log output", Group: "Logs & actions", Contexts: []string{contextLogs, contextAction}},
+	{Key: "t", Short: "Since", Help: "Show container logs for a time window (e.g. 10m)", Group: "Logs & actions", Contexts: []string{contextLogs}},
+
+	{Key: "r", Short: "Refresh", Help: "Reload the configuration", Group: "Configuration", Contexts: []string{contextConfig}},
+	{Key: "Space", Short: "Toggle passwords", Help: "Show or hide secret values", Group: "Configuration", Contexts: []string{contextConfig}},
+
+	{Key: "←", Short: "Previous", Help: "Previous field", Group: "Wizard", Contexts: []string{contextWizard}},
+	{Key: "→", Short: "Next", Help: "Next field (Enter also moves on)", Group: "Wizard", Contexts: []string{contextWizard}},
+	{Key: "PgUp/PgDn", Short: "Category", Help: "Previous or next category", Group: "Wizard", Contexts: []string{contextWizard}},
+	{Key: "Ctrl+R", Short: "Reset", Help: "Reset the field to its env.template default", Group: "Wizard", Contexts: []string{contextWizard}},
+	{Key: "Ctrl+G", Short: "Generate", Help: "Fill a secret field with a random value", Group: "Wizard", Contexts: []string{contextWizard}},
+	{Key: "Ctrl+F", Short: "Jump", Help: "Filter the variables and jump to one", Group: "Wizard", Contexts: []string{contextWizard}},
+	{Key: "Ctrl+S", Short: "Save", Help: "Review the changes, then save", Group: "Wizard", Contexts: []string{contextWizard}},
+	{Key: "Esc", Short: "Cancel", Help: "Discard changes and return to the dashboard", Group: "Wizard", Contexts: []string{contextWizard}},
+
+	{Key: "↑/↓", Short: "Navigate", Help: "Move between containers", Group: "Container selection", Contexts: []string{contextContainerSelection}},
+	{Key: "Space", Short: "Select/Deselect", Help: "Toggle a container (or click it)", Group: "Container selection", Contexts: []string{contextContainerSelection}},
+	{Key: "Enter", Short: "Confirm", Help: "Run the action on the selection", Group: "Container selection", Contexts: []string{contextContainerSelection}},
+	{Key: "Esc", Short: "Cancel", Help: "Return to the dashboard", Group: "Container selection", Contexts: []string{contextContainerSelection}},
+
+	{Key: "r", Short: "Refresh", Help: "Inspect the container again", Group: "Container details", Contexts: []string{contextContainerDetail}},
+	{Key: "Esc", Short: "Back", Help: "Return to the dashboard", Group: "Container details", Contexts: []string{contextContainerDetail}},
+
+	{Key: "↑/↓", Short: "Navigate", Help: "Move between files", Group: "Env file", Contexts: []string{contextEnvPicker}},
+	{Key: "→", Short: "Open", Help: "Open the highlighted directory", Group: "Env file", Contexts: []string{contextEnvPicker}},
+	{Key: "←", Short: "Up", Help: "Go to the parent directory", Group: "Env file", Contexts: []string{contextEnvPicker}},
+	{Key: "Enter", Short: "Use", Help: "Switch to the highlighted env file", Group: "Env file", Contexts: []string{contextEnvPicker}},
+	{Key: "Esc", Short: "Cancel", Help: "Keep the current env file", Group: "Env file", Contexts: []string{contextEnvPicker}},
+
+	{Key: "/", Short: "Search", Help: "Filter the cheatsheet", Group: "Cheatsheet", Contexts: []string{contextHelp}},
+	{Key: "Esc", Short: "Close", Help: "Close the cheatsheet or clear the search", Group: "Cheatsheet", Contexts: []string{contextHelp}},
+
+	{Key: "c", Short: "Sort by CPU", Help: "Sort containers by CPU usage", Group: "leyzenctl top", Contexts: []string{contextTop}},
+	{Key: "m", Short: "Sort by memory", Help: "Sort containers by memory usage", Group: "leyzenctl top", Contexts: []string{contextTop}},
+	{Key: "n", Short: "Sort by name", Help: "Sort containers by name", Group: "leyzenctl top", Contexts: []string{contextTop}},
+	{Key: "q/Ctrl+C", Short: "Quit", Help: "Leave the resource view (Esc also works)", Group: "leyzenctl top", Contexts: []string{contextTop}},
+}
+
+// bindingsFor returns the bindings shown in the footer of a context, in keymap order.
+func bindingsFor(context string) []keyBinding {
+	var out []keyBinding
+	for _, b := range keymap {
+		if b.Short == "" {
+			continue
+		}
+		for _, c := range b.Contexts {
+			if c == context {
+				out = append(out, b)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// matches reports whether the binding's key, description or section contains query.
+func (b keyBinding) matches(query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(b.Key), query) ||
+		strings.Contains(strings.ToLower(b.Help), query) ||
+		strings.Contains(strings.ToLower(b.Group), query)
+}
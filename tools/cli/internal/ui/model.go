@@ -2,23 +2,37 @@ package ui
 
 import (
 	"fmt"
+	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/filepicker"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"leyzenctl/internal"
 )
 
 type ContainerStatus struct {
-	Name      string
-	Status    string
-	Age       string
-	RawStatus string
+	Name         string
+	Status       string
+	Age          string
+	RawStatus    string
+	RestartCount int
+	ExitCode     int
+	// Consecutive healthcheck failures out of the retries docker allows, when known
+	HealthFailures int
+	HealthRetries  int
+	// Failed probes at the end of the health log, which also covers the start period
+	HealthLogFailures int
 }
 
 type ActionType string
@@ -31,6 +45,7 @@ const (
 	ActionBuild      ActionType = "build"
 	ActionConfigList ActionType = "config-list"
 	ActionWizard     ActionType = "wizard"
+	ActionLogs       ActionType = "logs"
 )
 
 type ViewState string
@@ -42,12 +57,21 @@ const (
 	ViewConfig             ViewState = "config"
 	ViewWizard             ViewState = "wizard"
 	ViewContainerSelection ViewState = "container-selection"
+	ViewContainerDetail    ViewState = "container-detail"
+	ViewEnvPicker          ViewState = "env-picker"
 )
 
 const (
 	statusRefreshInterval  = 500 * time.Millisecond
-	logBufferLimit         = 400
+	statusRefreshMin       = 100 * time.Millisecond
+	statusRefreshMax       = 8 * time.Second
+	statusBackoffStep      = 5 * time.Second
+	logBufferDefault       = 400
+	logBufferMax           = 100000 // Upper bound for LEYZENCTL_LOG_LINES
 	successMessageDuration = 5 * time.Second
+	transitionLogLimit     = 20
+	crashLoopWindow        = 2 * time.Minute // How long a restart keeps a service flagged as crash-looping
+	crashLogLines          = 5
 )
 
 type Theme struct {
@@ -65,8 +89,20 @@ type Theme struct {
 	Footer        lipgloss.Style
 }
 
+// StatusTransition records a service moving from one state to another.
+type StatusTransition struct {
+	At   time.Time
+	Name string
+	From string
+	To   string
+}
+
 type WizardField struct {
 	Key          string
+	Category     string
+	Summary      string // First line of the env.template documentation
+	Description  string // Full env.template documentation
+	Default      string // env.template value, empty when the template has none
 	Message      string
 	Value        string
 	IsPassword   bool
@@ -74,6 +110,18 @@ type WizardField struct {
 	Input        textinput.Model
 }
 
+// Changed reports whether saving would alter the loaded configuration. An empty input
+// keeps the existing value, so it is never a change.
+func (f WizardField) Changed() bool {
+	input := strings.TrimSpace(f.Input.Value())
+	return input != "" && input != f.Value
+}
+
+// Modified reports whether the field's current input differs from its template default.
+func (f WizardField) Modified() bool {
+	return f.Input.Value() != f.Default
+}
+
 type ContainerItem struct {
 	Name        string
 	Selected    bool
@@ -84,12 +132,15 @@ func (i ContainerItem) FilterValue() string { return i.Name }
 
 type Model struct {
 	envFile               string
+	projectNameFlag       string // --project-name, which takes precedence over the env file
 	statuses              []ContainerStatus
 	logs                  []string
 	logsRaw               []string // Raw logs without cleaning/filtering
 	logsBuffer            []string // Buffer to preserve logs when returning to dashboard
 	configPairs           map[string]string
 	configShowPasswords   map[string]bool
+	configDocs            map[string]internal.EnvDoc // env.template documentation by key
+	configDefaults        map[string]string          // env.template default values by key
 	viewport              viewport.Model
 	spinner               spinner.Model
 	width                 int
@@ -98,20 +149,56 @@ type Model struct {
 	action                ActionType
 	actionRunning         bool
 	actionStream          <-chan actionProgressMsg
+	buildStep             int // Last build step parsed from docker output, 0 when unknown
+	buildSteps            int
 	runner                *Runner
 	theme                 Theme
 	ready                 bool
 	pendingRefresh        bool
 	viewState             ViewState
 	successMessage        string
+	warningMessage        string // Shown in the warning color; cleared with successMessage
 	successTimer          *time.Timer
 	wizardFields          []WizardField
 	wizardIndex           int
 	wizardError           string
-	quitConfirm           bool // Quit confirmation
-	logModeRaw            bool // Whether we're in raw log view mode
-	viewportYOffsetNormal int  // Saved scroll position for normal mode
-	viewportYOffsetRaw    int  // Saved scroll position for raw mode
+	wizardProgress        progress.Model
+	quitConfirm           bool          // Quit confirmation
+	confirmAction         ActionType    // Destructive action waiting for a second key press
+	helpQuery             string        // Cheatsheet search filter
+	helpSearching         bool          // Typing into the cheatsheet search
+	wizardJumpActive      bool          // Wizard field filter is open
+	wizardConfirm         bool          // Wizard change summary awaiting confirmation
+	wizardJumpQuery       string        // Wizard field filter text
+	wizardJumpCursor      int           // Highlighted entry in the filtered field list
+	logModeRaw            bool          // Whether we're in raw log view mode
+	scrollLocked          bool          // Keep the logs viewport in place while new lines arrive
+	logWrap               bool          // Soft-wrap long log lines instead of truncating them
+	logsSince             string        // Active --since window of the logs view, empty for action history
+	logsSinceInput        bool          // Typing a --since window
+	logsSinceQuery        string        // --since window being typed
+	logsHistory           []string      // Action history set aside while a --since window is shown
+	logsRawHistory        []string      // Raw action history set aside while a --since window is shown
+	viewportYOffsetNormal int           // Saved scroll position for normal mode
+	viewportYOffsetRaw    int           // Saved scroll position for raw mode
+	lastStatusChange      time.Time     // Last time statuses changed or an action ran
+	refreshInterval       time.Duration // Base status refresh interval, from LEYZENCTL_REFRESH_MS
+	pollingPaused         bool          // Status polling suspended with p; f still refreshes once
+	logBufferLimit        int           // Log lines kept per mode, from LEYZENCTL_LOG_LINES
+	transitions           []StatusTransition
+	transitionsVisible    bool
+	restartCounts         map[string]int       // Restart count per service at the last refresh
+	crashLoops            map[string]time.Time // Crash-looping services and when the flag expires
+	crashLogs             map[string][]string  // Last log lines of crash-looping services
+	crashDetailsVisible   bool
+	statusCursor          int // Highlighted row of the status panel
+	containerDetail       *internal.ContainerDetail
+	containerDetailErr    error
+	containerDetailName   string   // Service shown in the detail view
+	setupIssues           []string // Env problems found at startup; the wizard opens until they are fixed
+	envPicker             filepicker.Model
+	envPickerErr          string // Why the picked file cannot be used
+	configReload          bool   // Config reload after an env file switch; keeps the wizard closed
 	// Container selection fields
 	containerList     list.Model
 	containerItems    []ContainerItem
@@ -120,7 +207,7 @@ type Model struct {
 	availableServices []string
 }
 
-func NewModel(envFile string, runner *Runner) *Model {
+func NewModel(envFile string, runner *Runner, themeName string) *Model {
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
 	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
@@ -128,22 +215,9 @@ func NewModel(envFile string, runner *Runner) *Model {
 	vp := viewport.New(0, 0)
 	vp.MouseWheelEnabled = true
 
-	theme := Theme{
-		Title:         lipgloss.NewStyle().Foreground(lipgloss.Color("#004225")).Bold(true),
-		Subtitle:      lipgloss.NewStyle().Foreground(lipgloss.Color("244")),
-		Pane:          lipgloss.NewStyle().Padding(1, 2).Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("238")),
-		ActiveStatus:  lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true),
-		ErrorStatus:   lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true),
-		WarningStatus: lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true),
-		HelpKey:       lipgloss.NewStyle().Foreground(lipgloss.Color("#004225")).Bold(true),
-		HelpDesc:      lipgloss.NewStyle().Foreground(lipgloss.Color("250")),
-		Spinner:       lipgloss.NewStyle().Foreground(lipgloss.Color("213")).Bold(true),
-		Accent:        lipgloss.NewStyle().Foreground(lipgloss.Color("#004225")).Bold(true),
-		SuccessStatus: lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true).Background(lipgloss.Color("235")),
-		Footer:        lipgloss.NewStyle().Foreground(lipgloss.Color("240")).MarginTop(1),
-	}
-
-	return &Model{
+	theme, themeErr := loadTheme(themeName)
+
+	m := &Model{
 		envFile:             envFile,
 		runner:              runner,
 		spinner:             sp,
@@ -152,19 +226,207 @@ func NewModel(envFile string, runner *Runner) *Model {
 		viewState:           ViewDashboard,
 		configPairs:         make(map[string]string),
 		configShowPasswords: make(map[string]bool),
+		restartCounts:       make(map[string]int),
+		crashLoops:          make(map[string]time.Time),
+		crashLogs:           make(map[string][]string),
+		lastStatusChange:    time.Now(),
+		refreshInterval:     loadRefreshInterval(),
+		logBufferLimit:      loadLogBufferLimit(),
 	}
+	if themeErr != nil {
+		m.warningMessage = fmt.Sprintf("[WARN] Using built-in theme: %v", themeErr)
+	}
+	return m
 }
 
 func (m *Model) Init() tea.Cmd {
-	return tea.Batch(m.spinner.Tick, fetchStatusesCmd(m.envFile), scheduleStatusRefresh())
+	cmds := []tea.Cmd{m.spinner.Tick, fetchStatusesCmd(m.envFile), scheduleStatusRefresh(m.statusRefreshDelay())}
+	if m.successMessage != "" || m.warningMessage != "" {
+		cmds = append(cmds, tea.Tick(successMessageDuration, func(time.Time) tea.Msg { return successTimeoutMsg{} }))
+	}
+	if len(m.setupIssues) > 0 || m.viewState == ViewConfig {
+		// Open the wizard straight away so the configuration can be fixed,
+		// or load the pairs for a restored config view
+		cmds = append(cmds, fetchConfigListCmd(m.envFile))
+	}
+	return tea.Batch(cmds...)
+}
+
+// restoreState applies the log mode and view saved by a previous session.
+// Only views that need no extra context (logs and config) are restored.
+func (m *Model) restoreState(settings internal.ProjectSettings) {
+	m.logModeRaw = settings.LogModeRaw
+	m.logWrap = settings.LogWrap
+	switch ViewState(settings.LastView) {
+	case ViewLogs:
+		m.switchToLogs()
+	case ViewConfig:
+		m.switchToConfig()
+	}
 }
 
-func scheduleStatusRefresh() tea.Cmd {
-	return tea.Tick(statusRefreshInterval, func(time.Time) tea.Msg {
+// saveState records the log mode and current view in the project settings.
+// Failures are ignored: the state is a convenience and must never block quitting.
+func (m *Model) saveState() {
+	settings, err := internal.LoadProjectSettings()
+	if err != nil {
+		return
+	}
+	settings.LogModeRaw = m.logModeRaw
+	settings.LogWrap = m.logWrap
+	settings.LastView = ""
+	if m.viewState == ViewLogs || m.viewState == ViewConfig {
+		settings.LastView = string(m.viewState)
+	}
+	_ = internal.SaveProjectSettings(settings)
+}
+
+func scheduleStatusRefresh(delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
 		return statusTickMsg{}
 	})
 }
 
+// loadRefreshInterval reads the base refresh interval from LEYZENCTL_REFRESH_MS,
+// falling back to statusRefreshInterval and never going below statusRefreshMin.
+func loadRefreshInterval() time.Duration {
+	raw := strings.TrimSpace(os.Getenv("LEYZENCTL_REFRESH_MS"))
+	if raw == "" {
+		return statusRefreshInterval
+	}
+	ms, err := strconv.Atoi(raw)
+	if err != nil || ms <= 0 {
+		return statusRefreshInterval
+	}
+	interval := time.Duration(ms) * time.Millisecond
+	if interval < statusRefreshMin {
+		interval = statusRefreshMin
+	}
+	return interval
+}
+
+// loadLogBufferLimit reads how many log lines the dashboard keeps from
+// LEYZENCTL_LOG_LINES, falling back to logBufferDefault and capped at logBufferMax.
+func loadLogBufferLimit() int {
+	raw := strings.TrimSpace(os.Getenv("LEYZENCTL_LOG_LINES"))
+	if raw == "" {
+		return logBufferDefault
+	}
+	lines, err := strconv.Atoi(raw)
+	if err != nil || lines <= 0 {
+		return logBufferDefault
+	}
+	return min(lines, logBufferMax)
+}
+
+// statusRefreshDelay returns the delay before the next status refresh.
+// The delay doubles for every idle step since the last change, up to statusRefreshMax
+// (or the base interval when that is configured higher).
+func (m *Model) statusRefreshDelay() time.Duration {
+	delay := m.refreshInterval
+	maxDelay := statusRefreshMax
+	if delay > maxDelay {
+		maxDelay = delay
+	}
+	steps := int(time.Since(m.lastStatusChange) / statusBackoffStep)
+	for i := 0; i < steps && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// markStatusActivity resets the refresh backoff so the dashboard polls quickly again.
+func (m *Model) markStatusActivity() {
+	m.lastStatusChange = time.Now()
+}
+
+// statusesChanged reports whether the service list or any service state differs.
+// Uptime text is ignored since it changes on every refresh.
+func statusesChanged(prev, next []ContainerStatus) bool {
+	if len(prev) != len(next) {
+		return true
+	}
+	for i := range prev {
+		if prev[i].Name != next[i].Name || statusState(prev[i].RawStatus) != statusState(next[i].RawStatus) {
+			return true
+		}
+	}
+	return false
+}
+
+// statusState reduces a docker status string to a stable state name.
+func statusState(raw string) string {
+	lower := strings.ToLower(strings.TrimSpace(raw))
+	switch {
+	case strings.Contains(lower, "unhealthy"):
+		return "unhealthy"
+	case strings.Contains(lower, "health: starting"):
+		return "starting"
+	case strings.Contains(lower, "healthy"):
+		return "healthy"
+	case strings.HasPrefix(lower, "restarting"):
+		return "restarting"
+	case strings.HasPrefix(lower, "up"):
+		return "up"
+	case strings.HasPrefix(lower, "exited"):
+		return "exited"
+	case lower == "":
+		return "unknown"
+	}
+	return lower
+}
+
+// recordTransitions appends an entry for every service whose state differs between snapshots.
+func (m *Model) recordTransitions(prev, next []ContainerStatus) {
+	previous := make(map[string]string, len(prev))
+	for _, st := range prev {
+		previous[st.Name] = statusState(st.RawStatus)
+	}
+	now := time.Now()
+	for _, st := range next {
+		from, ok := previous[st.Name]
+		to := statusState(st.RawStatus)
+		if !ok || from == to {
+			continue
+		}
+		m.transitions = append(m.transitions, StatusTransition{At: now, Name: st.Name, From: from, To: to})
+	}
+	if len(m.transitions) > transitionLogLimit {
+		m.transitions = m.transitions[len(m.transitions)-transitionLogLimit:]
+	}
+}
+
+// trackRestarts flags services whose restart count grew since the previous refresh
+// and returns commands fetching their latest log lines.
+func (m *Model) trackRestarts(statuses []ContainerStatus) []tea.Cmd {
+	var cmds []tea.Cmd
+	now := time.Now()
+	for _, st := range statuses {
+		prev, seen := m.restartCounts[st.Name]
+		m.restartCounts[st.Name] = st.RestartCount
+		if seen && st.RestartCount > prev {
+			m.crashLoops[st.Name] = now.Add(crashLoopWindow)
+			cmds = append(cmds, fetchCrashLogsCmd(m.envFile, st.Name))
+		}
+	}
+	for name, until := range m.crashLoops {
+		if now.After(until) {
+			delete(m.crashLoops, name)
+			delete(m.crashLogs, name)
+		}
+	}
+	return cmds
+}
+
+// isCrashLooping reports whether the service restarted within crashLoopWindow.
+func (m *Model) isCrashLooping(name string) bool {
+	until, ok := m.crashLoops[name]
+	return ok && time.Now().Before(until)
+}
+
 func (m *Model) appendLog(line string, lineRaw string) {
 	if line == "" {
 		return
@@ -178,8 +440,8 @@ func (m *Model) appendLog(line string, lineRaw string) {
 	// Store raw line before any cleaning/filtering
 	if lineRaw != "" {
 		m.logsRaw = append(m.logsRaw, lineRaw)
-		if len(m.logsRaw) > logBufferLimit {
-			diff := len(m.logsRaw) - logBufferLimit
+		if len(m.logsRaw) > m.logBufferLimit {
+			diff := len(m.logsRaw) - m.logBufferLimit
 			m.logsRaw = m.logsRaw[diff:]
 		}
 	}
@@ -213,30 +475,25 @@ func (m *Model) appendLog(line string, lineRaw string) {
 	}
 
 	m.logs = append(m.logs, line)
-	if len(m.logs) > logBufferLimit {
-		diff := len(m.logs) - logBufferLimit
+	if len(m.logs) > m.logBufferLimit {
+		diff := len(m.logs) - m.logBufferLimit
 		m.logs = m.logs[diff:]
 	}
 	// Only update viewport if we're in a view that displays logs
 	if m.viewState == ViewLogs || m.viewState == ViewAction {
-		// Determine which logs to display based on mode
-		var content string
 		if m.logModeRaw {
-			content = strings.Join(m.logsRaw, "\n")
 			// In raw mode, viewport takes full screen
 			m.viewport.Width = m.width
 			m.viewport.Height = m.height
-		} else {
-			content = strings.Join(m.logs, "\n")
 		}
 
 		// Check if user is already at the bottom before updating
 		wasAtBottom := m.isViewportAtBottom()
 
-		m.viewport.SetContent(content)
+		m.setLogContent(m.displayedLogs())
 
-		// Only auto-scroll to bottom if user was already at bottom
-		if wasAtBottom {
+		// Only auto-scroll to bottom if user was already at bottom and has not locked scrolling
+		if wasAtBottom && !m.scrollLocked {
 			m.viewport.GotoBottom()
 			// Save the position after auto-scroll
 			if m.logModeRaw {
@@ -255,28 +512,105 @@ func (m *Model) appendLog(line string, lineRaw string) {
 	}
 }
 
-// isViewportAtBottom checks if the viewport is currently scrolled to the bottom
-func (m *Model) isViewportAtBottom() bool {
-	// Get the actual content (not the rendered view)
-	var content string
+// toggleScrollLock stops or resumes following new log lines. Resuming jumps back to
+// the latest line.
+func (m *Model) toggleScrollLock() {
+	m.scrollLocked = !m.scrollLocked
+	if m.scrollLocked {
+		return
+	}
+	m.viewport.GotoBottom()
 	if m.logModeRaw {
-		content = strings.Join(m.logsRaw, "\n")
+		m.viewportYOffsetRaw = m.viewport.YOffset
 	} else {
-		content = strings.Join(m.logs, "\n")
+		m.viewportYOffsetNormal = m.viewport.YOffset
 	}
+}
 
-	if content == "" {
+// isViewportAtBottom checks if the viewport is currently scrolled to the bottom
+func (m *Model) isViewportAtBottom() bool {
+	// Count the lines as laid out in the viewport, wrapped lines included
+	totalLines := m.viewport.TotalLineCount()
+	if totalLines == 0 {
 		return true
 	}
 
-	lines := strings.Split(content, "\n")
-	totalLines := len(lines)
-
 	// Check if we're at or near the bottom (within 2 lines to account for rounding)
 	return m.viewport.YOffset+m.viewport.Height >= totalLines-2
 }
 
+// displayedLogs returns the log lines of the active log mode.
+func (m *Model) displayedLogs() []string {
+	if m.logModeRaw {
+		return m.logsRaw
+	}
+	return m.logs
+}
+
+// setLogContent lays the log lines out for the viewport: soft-wrapped to its width
+// when wrapping is on, cut at the width otherwise.
+func (m *Model) setLogContent(lines []string) {
+	width := m.viewport.Width
+	laidOut := make([]string, 0, len(lines))
+	for _, line := range lines {
+		switch {
+		case width <= 0:
+			laidOut = append(laidOut, line)
+		case m.logWrap:
+			laidOut = append(laidOut, strings.Split(ansi.Wrap(line, width, ""), "\n")...)
+		default:
+			laidOut = append(laidOut, ansi.Truncate(line, width, "…"))
+		}
+	}
+	m.viewport.SetContent(strings.Join(laidOut, "\n"))
+}
+
+// logLineHeight is the number of viewport lines a log line takes up.
+func (m *Model) logLineHeight(line string) int {
+	if !m.logWrap || m.viewport.Width <= 0 {
+		return 1
+	}
+	return strings.Count(ansi.Wrap(line, m.viewport.Width, ""), "\n") + 1
+}
+
+// toggleLogWrap switches between wrapped and truncated log lines, keeping the log
+// line at the top of the viewport in place as the line count changes.
+func (m *Model) toggleLogWrap() {
+	lines := m.displayedLogs()
+	atBottom := m.viewport.AtBottom()
+
+	top, offset := 0, 0
+	for top < len(lines) {
+		height := m.logLineHeight(lines[top])
+		if offset+height > m.viewport.YOffset {
+			break
+		}
+		offset += height
+		top++
+	}
+
+	m.logWrap = !m.logWrap
+	m.setLogContent(lines)
+
+	if atBottom {
+		m.viewport.GotoBottom()
+	} else {
+		offset = 0
+		for _, line := range lines[:top] {
+			offset += m.logLineHeight(line)
+		}
+		m.viewport.SetYOffset(offset)
+	}
+	if m.logModeRaw {
+		m.viewportYOffsetRaw = m.viewport.YOffset
+	} else {
+		m.viewportYOffsetNormal = m.viewport.YOffset
+	}
+}
+
 func (m *Model) switchToDashboard() {
+	// The buffer keeps the action history, not the container logs of a --since window
+	m.clearLogsSince()
 	if m.viewState == ViewLogs || m.viewState == ViewAction {
 		m.logsBuffer = make([]string, len(m.logs))
 		copy(m.logsBuffer, m.logs)
@@ -326,7 +660,7 @@ func (m *Model) switchToLogs() {
 	}
 
 	if len(logsToDisplay) > 0 {
-		m.viewport.SetContent(strings.Join(logsToDisplay, "\n"))
+		m.setLogContent(logsToDisplay)
 		if m.logModeRaw {
 			if m.viewportYOffsetRaw > 0 {
 				m.viewport.SetYOffset(m.viewportYOffsetRaw)
@@ -368,7 +702,7 @@ func (m *Model) switchToAction() {
 	}
 
 	if len(logsToDisplay) > 0 {
-		m.viewport.SetContent(strings.Join(logsToDisplay, "\n"))
+		m.setLogContent(logsToDisplay)
 		// Restore saved scroll position or go to bottom
 		if m.logModeRaw {
 			if m.viewportYOffsetRaw > 0 {
@@ -399,6 +733,19 @@ func (m *Model) switchToAction() {
 	}
 }
 
+// switchToContainerDetail opens the detail view of the highlighted service and
+// returns the command that inspects its container.
+func (m *Model) switchToContainerDetail() tea.Cmd {
+	if m.statusCursor >= len(m.statuses) {
+		return nil
+	}
+	m.containerDetailName = m.statuses[m.statusCursor].Name
+	m.containerDetail = nil
+	m.containerDetailErr = nil
+	m.viewState = ViewContainerDetail
+	return fetchContainerDetailCmd(m.envFile, m.containerDetailName)
+}
+
 func (m *Model) switchToConfig() {
 	m.viewState = ViewConfig
 	// Initialize config viewport size if window is already sized
@@ -417,22 +764,106 @@ func (m *Model) switchToConfig() {
 	}
 }
 
-func (m *Model) initWizard(existing map[string]string) {
-	keys := make([]string, 0, len(existing))
-	for k := range existing {
-		keys = append(keys, k)
+// wizardJumpMatches returns the indexes of wizard fields matching the jump filter.
+// Keys containing the query come first, followed by fuzzy (in-order letters) matches.
+func (m *Model) wizardJumpMatches() []int {
+	query := strings.ToLower(strings.TrimSpace(m.wizardJumpQuery))
+	var exact, fuzzy []int
+	for i, field := range m.wizardFields {
+		key := strings.ToLower(field.Key)
+		switch {
+		case strings.Contains(key, query):
+			exact = append(exact, i)
+		case fuzzyMatch(query, key):
+			fuzzy = append(fuzzy, i)
+		}
+	}
+	return append(exact, fuzzy...)
+}
+
+// fuzzyMatch reports whether every rune of query appears in s in order.
+func fuzzyMatch(query, s string) bool {
+	runes := []rune(query)
+	if len(runes) == 0 {
+		return true
+	}
+	i := 0
+	for _, r := range s {
+		if r == runes[i] {
+			i++
+			if i == len(runes) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nextWizardCategory returns the index of the first field in the next (dir > 0) or
+// current/previous (dir < 0) category, wrapping around the field list.
+func (m *Model) nextWizardCategory(dir int) int {
+	n := len(m.wizardFields)
+	if n == 0 {
+		return 0
+	}
+	current := m.wizardFields[m.wizardIndex].Category
+	if dir > 0 {
+		for i := 1; i < n; i++ {
+			idx := (m.wizardIndex + i) % n
+			if m.wizardFields[idx].Category != current || idx == 0 {
+				return idx
+			}
+		}
+		return m.wizardIndex
+	}
+
+	// Go to the start of the current category, or of the previous one when already there
+	start := m.wizardIndex
+	for start > 0 && m.wizardFields[start-1].Category == current {
+		start--
 	}
+	if start != m.wizardIndex {
+		return start
+	}
+	prev := (start - 1 + n) % n
+	category := m.wizardFields[prev].Category
+	for prev > 0 && m.wizardFields[prev-1].Category == category {
+		prev--
+	}
+	return prev
+}
 
-	// Sort keys for consistent display
-	sort.Strings(keys)
+// focusWizardField moves the wizard to the field at idx.
+func (m *Model) focusWizardField(idx int) {
+	if idx < 0 || idx >= len(m.wizardFields) {
+		return
+	}
+	m.wizardFields[m.wizardIndex].Input.Blur()
+	m.wizardIndex = idx
+	field := &m.wizardFields[m.wizardIndex]
+	field.Input.Focus()
+	field.Input.CursorEnd()
+	m.wizardError = ""
+}
+
+func (m *Model) initWizard(existing map[string]string) {
+	m.wizardConfirm = false
+	m.wizardProgress = progress.New(progress.WithDefaultGradient(), progress.WithoutPercentage(), progress.WithWidth(40))
+
+	// Group keys by category, in the same order as the config view
+	categories := m.categorizeConfigPairs(existing)
+	var keys, keyCategories []string
+	for _, category := range configCategoryOrder {
+		for _, key := range categories[category] {
+			keys = append(keys, key)
+			keyCategories = append(keyCategories, category)
+		}
+	}
 
 	m.wizardFields = make([]WizardField, len(keys))
 	for i, key := range keys {
 		existingValue := existing[key]
-		isPassword := strings.Contains(strings.ToLower(key), "password") ||
-			strings.Contains(strings.ToLower(key), "secret") ||
-			strings.Contains(strings.ToLower(key), "token") ||
-			strings.Contains(strings.ToLower(key), "key")
+		isPassword := internal.IsSecretKey(key)
 
 		ti := textinput.New()
 		ti.Placeholder = fmt.Sprintf("Value for %s", key)
@@ -442,8 +873,13 @@ func (m *Model) initWizard(existing map[string]string) {
 			ti.SetValue(existingValue)
 		}
 
+		doc := m.configDocs[key]
 		m.wizardFields[i] = WizardField{
 			Key:          key,
+			Category:     keyCategories[i],
+			Summary:      doc.Summary,
+			Description:  doc.Description,
+			Default:      m.configDefaults[key],
 			Message:      key,
 			Value:        existingValue,
 			IsPassword:   isPassword,
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"leyzenctl/internal"
+)
+
+// chdirRepo makes a temporary directory look like the repository root, where the
+// project settings file lives, and changes into it for the duration of the test.
+func chdirRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range []string{"leyzenctl", "env.template"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+	return dir
+}
+
+func TestDashboardStateSurvivesSaveAndReload(t *testing.T) {
+	chdirRepo(t)
+
+	m := NewModel(".env", NewRunner(".env"), "dark")
+	m.logModeRaw = true
+	m.logWrap = true
+	m.switchToLogs()
+	m.saveState()
+
+	settings, err := internal.LoadProjectSettings()
+	if err != nil {
+		t.Fatalf("LoadProjectSettings: %v", err)
+	}
+	restored := NewModel(".env", NewRunner(".env"), "dark")
+	restored.restoreState(settings)
+
+	if !restored.logModeRaw || !restored.logWrap {
+		t.Errorf("log mode not restored: raw=%v wrap=%v", restored.logModeRaw, restored.logWrap)
+	}
+	if restored.viewState != ViewLogs {
+		t.Errorf("view = %q, want %q", restored.viewState, ViewLogs)
+	}
+}
+
+func TestDefaultDashboardStateRemovesSettingsFile(t *testing.T) {
+	dir := chdirRepo(t)
+
+	m := NewModel(".env", NewRunner(".env"), "dark")
+	m.saveState()
+
+	if _, err := os.Stat(filepath.Join(dir, internal.ProjectSettingsFileName)); !os.IsNotExist(err) {
+		t.Errorf("settings file written for the default state (stat err: %v)", err)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -21,19 +24,45 @@ func NewRunner(envFile string) *Runner {
 	return &Runner{envFile: envFile}
 }
 
-func StartApp(ctx context.Context, envFile string) error {
+// StartApp runs the dashboard. projectName is the --project-name flag, which keeps
+// overriding COMPOSE_PROJECT_NAME when another env file is picked.
+func StartApp(ctx context.Context, envFile, projectName, themeName string) error {
 	resolvedEnv, err := internal.ResolveEnvFilePath(envFile)
 	if err != nil {
 		return err
 	}
 
+	// Catch a missing or broken env file before the dashboard hides it behind empty panels
+	setupIssues, err := internal.PreflightEnv(resolvedEnv)
+	if err != nil {
+		return fmt.Errorf("cannot start dashboard: %w", err)
+	}
+
+	// The regeneration diff would flood the action log, so keep it to CLI commands
+	internal.SetGeneratedDiffOutput(false)
+
+	// Compose only colors its output on a terminal; ask for colors anyway so the raw
+	// log view shows them. The cleaned view strips them again.
+	if os.Getenv("COMPOSE_ANSI") == "" && !color.NoColor {
+		os.Setenv("COMPOSE_ANSI", "always")
+	}
+
 	// Ensure docker-generated.yml exists at startup (silently, no logs)
-	if err := internal.EnsureDockerGeneratedFileWithWriter(io.Discard, io.Discard, resolvedEnv); err != nil {
-		return fmt.Errorf("failed to initialize docker-generated.yml: %w", err)
+	if len(setupIssues) == 0 {
+		if err := internal.EnsureDockerGeneratedFileWithWriter(io.Discard, io.Discard, resolvedEnv); err != nil {
+			return fmt.Errorf("failed to initialize docker-generated.yml: %w", err)
+		}
 	}
 
 	runner := NewRunner(resolvedEnv)
-	model := NewModel(resolvedEnv, runner)
+	model := NewModel(resolvedEnv, runner, themeName)
+	model.projectNameFlag = projectName
+	model.setupIssues = setupIssues
+	if len(setupIssues) == 0 {
+		if settings, err := internal.LoadProjectSettings(); err == nil {
+			model.restoreState(settings)
+		}
+	}
 
 	options := []tea.ProgramOption{tea.WithAltScreen()}
 	if ctx != nil {
@@ -44,6 +73,7 @@ func StartApp(ctx context.Context, envFile string) error {
 	if _, err := program.Run(); err != nil {
 		return err
 	}
+	model.saveState()
 	return nil
 }
 
@@ -91,6 +121,27 @@ func (r *Runner) RunWithServices(action ActionType, services []string) (<-chan a
 	return stream, nil
 }
 
+// Logs streams `docker compose logs --since` for every service. The command does not
+// follow, so the stream ends once the requested window has been printed.
+func (r *Runner) Logs(since string) (<-chan actionProgressMsg, error) {
+	stream := make(chan actionProgressMsg, 64)
+
+	go func() {
+		defer close(stream)
+		writer := newActionWriter(ActionLogs, stream)
+		err := internal.RunComposeWithWriter(writer, writer, r.envFile, "logs", "--no-color", "--since", since)
+		writer.flush()
+
+		if err != nil {
+			stream <- actionProgressMsg{Action: ActionLogs, Err: err}
+			return
+		}
+		stream <- actionProgressMsg{Action: ActionLogs, Done: true}
+	}()
+
+	return stream, nil
+}
+
 func (r *Runner) restart(writer *actionWriter) error {
 	return r.restartWithServices(writer, []string{})
 }
@@ -102,6 +153,18 @@ func (r *Runner) restartWithServices(writer *actionWriter, services []string) er
 		return err
 	}
 
+	// Only the vault web containers hold files in tmpfs; other services restart right away
+	switch {
+	case !internal.StopsVaultWeb(r.envFile, services):
+	case internal.DryRun():
+		writer.emit(color.HiYellowString("[dry-run] Would promote tmpfs files to persistent storage"))
+	default:
+		writer.emit(color.HiYellowString("Promoting files to persistent storage..."))
+		if err := internal.PrepareRotation(r.envFile); err != nil {
+			writer.emit(color.HiYellowString(fmt.Sprintf("[WARN] Failed to promote files before restart: %v", err)))
+		}
+	}
+
 	if err := r.stopWithServices(writer, services); err != nil {
 		return err
 	}
@@ -181,9 +244,12 @@ func fetchConfigListCmd(envFile string) tea.Cmd {
 		// Load all variables from template + .env (template values are defaults, .env values take priority)
 		pairs, err := internal.LoadAllEnvVariables(envFile)
 		if err != nil {
-			return configListMsg{err: err}
+			return configListMsg{envFile: envFile, err: err}
 		}
-		return configListMsg{pairs: pairs}
+		// Documentation and defaults only feed the wizard, so a template that cannot be parsed is not fatal
+		docs, _ := internal.LoadEnvDocumentation(envFile)
+		defaults, _ := internal.LoadEnvTemplate(envFile)
+		return configListMsg{envFile: envFile, pairs: pairs, docs: docs, defaults: defaults}
 	}
 }
 
@@ -236,36 +302,7 @@ func (w *actionWriter) Write(p []byte) (int, error) {
 			break
 		}
 
-		lineRaw := strings.TrimSuffix(data[:idx], "\r")
-		line := strings.Trim(lineRaw, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0a\x0b\x0c\x0d\x0e\x0f\x10\x11\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x1c\x1d\x1e\x1f")
-		line = strings.TrimSpace(line)
-
-		if line == "" {
-			data = data[idx+1:]
-			continue
-		}
-
-		if len(line) == 1 {
-			validSingleChars := map[string]bool{
-				"[": true,
-				"]": true,
-				"(": true,
-				")": true,
-				"{": true,
-				"}": true,
-			}
-			if !validSingleChars[line] {
-				data = data[idx+1:]
-				continue
-			}
-		}
-
-		if len(line) == 1 && line >= "a" && line <= "z" {
-			data = data[idx+1:]
-			continue
-		}
-
-		w.stream <- actionProgressMsg{Action: w.action, Line: line, LineRaw: lineRaw}
+		w.send(data[:idx])
 		data = data[idx+1:]
 	}
 
@@ -279,51 +316,141 @@ func (w *actionWriter) flush() {
 	if w.buf.Len() == 0 {
 		return
 	}
+	w.send(w.buf.String())
+	w.buf.Reset()
+}
 
-	lineRaw := w.buf.String()
-	line := strings.Trim(lineRaw, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0a\x0b\x0c\x0d\x0e\x0f\x10\x11\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x1c\x1d\x1e\x1f")
-	line = strings.TrimSpace(line)
-
-	if line != "" {
-		if len(line) == 1 {
-			validSingleChars := map[string]bool{
-				"[": true,
-				"]": true,
-				"(": true,
-				")": true,
-				"{": true,
-				"}": true,
-			}
-			if !validSingleChars[line] {
-				w.buf.Reset()
-				return
-			}
+// send streams one output line: colors kept for the raw view, plain text for the
+// cleaned view.
+func (w *actionWriter) send(output string) {
+	line := cleanLogLine(output)
+	if !keepLogLine(line) {
+		return
+	}
+	msg := actionProgressMsg{Action: w.action, Line: line, LineRaw: rawLogLine(output)}
+	if w.action == ActionBuild {
+		msg.Step, msg.Steps = parseBuildStep(line)
+	}
+	w.stream <- msg
+}
+
+// lastRedraw returns the text after the last carriage return, which is what a
+// terminal shows once a progress line has been redrawn in place.
+func lastRedraw(output string) string {
+	output = strings.TrimSuffix(output, "\r")
+	if idx := strings.LastIndexByte(output, '\r'); idx != -1 {
+		return output[idx+1:]
+	}
+	return output
+}
+
+// cleanLogLine returns the plain text of an output line: escape sequences and other
+// control characters removed.
+func cleanLogLine(output string) string {
+	line := ansiRegex.ReplaceAllString(lastRedraw(output), "")
+	line = strings.Map(func(r rune) rune {
+		if (r < 0x20 && r != '\t') || r == 0x7f {
+			return -1
 		}
-		if len(line) == 1 && line >= "a" && line <= "z" {
-			w.buf.Reset()
-			return
+		return r
+	}, line)
+	return strings.TrimSpace(line)
+}
+
+// rawLogLine keeps the color and style (SGR) sequences of an output line but drops
+// cursor movement and erase sequences, which cannot be replayed in a scrolling viewport.
+func rawLogLine(output string) string {
+	return ansiRegex.ReplaceAllStringFunc(lastRedraw(output), func(seq string) string {
+		if strings.HasSuffix(seq, "m") {
+			return seq
+		}
+		return ""
+	})
+}
+
+// keepLogLine filters out blank lines and the stray single characters that progress
+// redraws leave behind.
+func keepLogLine(line string) bool {
+	if line == "" {
+		return false
+	}
+	if len(line) == 1 {
+		switch line {
+		case "[", "]", "(", ")", "{", "}":
+			return true
 		}
-		w.stream <- actionProgressMsg{Action: w.action, Line: line, LineRaw: lineRaw}
+		return false
 	}
-	w.buf.Reset()
+	return true
+}
+
+var (
+	// Classic builder: "Step 6/14 : RUN ..."
+	classicStepRegex = regexp.MustCompile(`\bStep (\d+)/(\d+)\b`)
+	// BuildKit: "#8 [web 3/7] RUN ..." or " => [vault_web1 3/7] RUN ..."
+	buildkitStepRegex = regexp.MustCompile(`\[(?:[^\]]* )?(\d+)/(\d+)\]`)
+)
+
+// parseBuildStep extracts the "X/Y" build step from a docker build line. It returns
+// zeros when the line carries no step marker.
+func parseBuildStep(line string) (int, int) {
+	match := classicStepRegex.FindStringSubmatch(line)
+	if match == nil {
+		match = buildkitStepRegex.FindStringSubmatch(line)
+	}
+	if match == nil {
+		return 0, 0
+	}
+	step, _ := strconv.Atoi(match[1])
+	steps, _ := strconv.Atoi(match[2])
+	if steps == 0 || step > steps {
+		return 0, 0
+	}
+	return step, steps
 }
 
 func fetchStatusesCmd(envFile string) tea.Cmd {
 	return func() tea.Msg {
 		projectStatuses, err := internal.GetProjectStatuses(envFile)
 		if err != nil {
-			return statusMsg{err: err}
+			return statusMsg{envFile: envFile, err: err}
 		}
 
+		// Restart data is best effort; without it crash loops are simply not flagged
+		restarts, _ := internal.GetRestartInfo(envFile)
+
 		var statuses []ContainerStatus
 		for _, ps := range projectStatuses {
 			statuses = append(statuses, ContainerStatus{
-				Name:      ps.Name,
-				Status:    ps.Status,
-				RawStatus: ps.Status,
-				Age:       ps.Age,
+				Name:              ps.Name,
+				Status:            ps.Status,
+				RawStatus:         ps.Status,
+				Age:               ps.Age,
+				RestartCount:      restarts[ps.Name].RestartCount,
+				ExitCode:          restarts[ps.Name].ExitCode,
+				HealthFailures:    restarts[ps.Name].HealthFailures,
+				HealthRetries:     restarts[ps.Name].HealthRetries,
+				HealthLogFailures: restarts[ps.Name].HealthLogFailures,
 			})
 		}
-		return statusMsg{statuses: statuses}
+		return statusMsg{envFile: envFile, statuses: statuses}
+	}
+}
+
+func fetchCrashLogsCmd(envFile, name string) tea.Cmd {
+	return func() tea.Msg {
+		lines, _ := internal.ServiceLogTail(envFile, name, crashLogLines)
+		return crashLogsMsg{name: name, lines: lines}
+	}
+}
+
+func fetchContainerDetailCmd(envFile, service string) tea.Cmd {
+	return func() tea.Msg {
+		name, err := internal.ContainerForService(envFile, service)
+		if err != nil {
+			return containerDetailMsg{service: service, err: err}
+		}
+		detail, err := internal.InspectContainer(name)
+		return containerDetailMsg{service: service, detail: detail, err: err}
 	}
 }
@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+	"github.com/muesli/termenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Built-in color schemes selectable with --theme.
+const (
+	ThemeDark  = "dark"
+	ThemeLight = "light"
+	ThemeNone  = "none"
+)
+
+// ValidateThemeName rejects unknown --theme values.
+func ValidateThemeName(name string) error {
+	switch name {
+	case ThemeDark, ThemeLight, ThemeNone:
+		return nil
+	}
+	return fmt.Errorf("unknown theme %q (expected %s, %s or %s)", name, ThemeDark, ThemeLight, ThemeNone)
+}
+
+// ThemeColors lists the colors a theme file may override. Values are lipgloss
+// colors: ANSI 256 codes ("42") or hex strings ("#004225"). Empty values keep the default.
+type ThemeColors struct {
+	Title             string `yaml:"title" json:"title"`
+	Subtitle          string `yaml:"subtitle" json:"subtitle"`
+	Border            string `yaml:"border" json:"border"`
+	Active            string `yaml:"active" json:"active"`
+	Error             string `yaml:"error" json:"error"`
+	Warning           string `yaml:"warning" json:"warning"`
+	HelpKey           string `yaml:"help_key" json:"help_key"`
+	HelpDesc          string `yaml:"help_desc" json:"help_desc"`
+	Spinner           string `yaml:"spinner" json:"spinner"`
+	Accent            string `yaml:"accent" json:"accent"`
+	Success           string `yaml:"success" json:"success"`
+	SuccessBackground string `yaml:"success_background" json:"success_background"`
+	Footer            string `yaml:"footer" json:"footer"`
+}
+
+func defaultThemeColors() ThemeColors {
+	return ThemeColors{
+		Title:             "#004225",
+		Subtitle:          "244",
+		Border:            "238",
+		Active:            "42",
+		Error:             "196",
+		Warning:           "214",
+		HelpKey:           "#004225",
+		HelpDesc:          "250",
+		Spinner:           "213",
+		Accent:            "#004225",
+		Success:           "42",
+		SuccessBackground: "235",
+		Footer:            "240",
+	}
+}
+
+// lightThemeColors uses darker foregrounds that stay readable on light terminals.
+func lightThemeColors() ThemeColors {
+	return ThemeColors{
+		Title:             "#004225",
+		Subtitle:          "240",
+		Border:            "250",
+		Active:            "28",
+		Error:             "160",
+		Warning:           "130",
+		HelpKey:           "#004225",
+		HelpDesc:          "236",
+		Spinner:           "127",
+		Accent:            "#004225",
+		Success:           "28",
+		SuccessBackground: "255",
+		Footer:            "244",
+	}
+}
+
+// merge returns c with every non-empty field of override applied.
+func (c ThemeColors) merge(override ThemeColors) ThemeColors {
+	pick := func(base, over string) string {
+		if strings.TrimSpace(over) != "" {
+			return strings.TrimSpace(over)
+		}
+		return base
+	}
+	return ThemeColors{
+		Title:             pick(c.Title, override.Title),
+		Subtitle:          pick(c.Subtitle, override.Subtitle),
+		Border:            pick(c.Border, override.Border),
+		Active:            pick(c.Active, override.Active),
+		Error:             pick(c.Error, override.Error),
+		Warning:           pick(c.Warning, override.Warning),
+		HelpKey:           pick(c.HelpKey, override.HelpKey),
+		HelpDesc:          pick(c.HelpDesc, override.HelpDesc),
+		Spinner:           pick(c.Spinner, override.Spinner),
+		Accent:            pick(c.Accent, override.Accent),
+		Success:           pick(c.Success, override.Success),
+		SuccessBackground: pick(c.SuccessBackground, override.SuccessBackground),
+		Footer:            pick(c.Footer, override.Footer),
+	}
+}
+
+func newTheme(c ThemeColors) Theme {
+	return Theme{
+		Title:         lipgloss.NewStyle().Foreground(lipgloss.Color(c.Title)).Bold(true),
+		Subtitle:      lipgloss.NewStyle().Foreground(lipgloss.Color(c.Subtitle)),
+		Pane:          lipgloss.NewStyle().Padding(1, 2).Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color(c.Border)),
+		ActiveStatus:  lipgloss.NewStyle().Foreground(lipgloss.Color(c.Active)).Bold(true),
+		ErrorStatus:   lipgloss.NewStyle().Foreground(lipgloss.Color(c.Error)).Bold(true),
+		WarningStatus: lipgloss.NewStyle().Foreground(lipgloss.Color(c.Warning)).Bold(true),
+		HelpKey:       lipgloss.NewStyle().Foreground(lipgloss.Color(c.HelpKey)).Bold(true),
+		HelpDesc:      lipgloss.NewStyle().Foreground(lipgloss.Color(c.HelpDesc)),
+		Spinner:       lipgloss.NewStyle().Foreground(lipgloss.Color(c.Spinner)).Bold(true),
+		Accent:        lipgloss.NewStyle().Foreground(lipgloss.Color(c.Accent)).Bold(true),
+		SuccessStatus: lipgloss.NewStyle().Foreground(lipgloss.Color(c.Success)).Bold(true).Background(lipgloss.Color(c.SuccessBackground)),
+		Footer:        lipgloss.NewStyle().Foreground(lipgloss.Color(c.Footer)).MarginTop(1),
+	}
+}
+
+// loadTheme builds the dashboard theme for the named scheme, applying the file named by
+// LEYZEN_THEME_FILE (JSON or YAML) on top of its colors. The none scheme ignores the file;
+// its styles are stripped by the ASCII color profile set in DisableColors. On error the
+// scheme's built-in theme is returned together with the error so the caller can report it.
+func loadTheme(name string) (Theme, error) {
+	colors := defaultThemeColors()
+	switch name {
+	case ThemeLight:
+		colors = lightThemeColors()
+	case ThemeNone:
+		return newTheme(colors), nil
+	}
+	path := strings.TrimSpace(os.Getenv("LEYZEN_THEME_FILE"))
+	if path == "" {
+		return newTheme(colors), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return newTheme(colors), fmt.Errorf("read theme file: %w", err)
+	}
+	// YAML is a superset of JSON, so one decoder handles both formats
+	var override ThemeColors
+	if err := yaml.Unmarshal(data, &override); err != nil {
+		return newTheme(colors), fmt.Errorf("parse theme file %s: %w", path, err)
+	}
+	return newTheme(colors.merge(override)), nil
+}
+
+// DisableColors turns off ANSI styling for both lipgloss and fatih/color output.
+func DisableColors() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+	color.NoColor = true
+}
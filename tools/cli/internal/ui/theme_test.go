@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+	"github.com/muesli/termenv"
+)
+
+func TestNoneThemeRendersWithoutANSI(t *testing.T) {
+	previousProfile := lipgloss.ColorProfile()
+	previousNoColor := color.NoColor
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(previousProfile)
+		color.NoColor = previousNoColor
+	})
+	chdirRepo(t)
+
+	render := func(themeName string) string {
+		m := NewModel(".env", NewRunner(".env"), themeName)
+		m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		m.statuses = []ContainerStatus{{Name: "vault_web1", Status: "Up 5 minutes (healthy)", RawStatus: "running"}}
+		return m.View() + m.theme.ErrorStatus.Render("error") + color.HiRedString("error")
+	}
+
+	// A color profile is forced so the check does not pass merely because tests run without a TTY
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	color.NoColor = false
+	if out := render(ThemeDark); !strings.Contains(out, "\x1b[") {
+		t.Fatal("dark theme rendered no ANSI escapes; the test cannot tell themes apart")
+	}
+
+	DisableColors()
+	if out := render(ThemeNone); strings.Contains(out, "\x1b[") {
+		t.Errorf("none theme rendered ANSI escapes:\n%q", out)
+	}
+}
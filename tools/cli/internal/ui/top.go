@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+
+	"leyzenctl/internal"
+	"leyzenctl/internal/status"
+)
+
+// Sort orders of the top view.
+const (
+	TopSortCPU    = "cpu"
+	TopSortMemory = "mem"
+	TopSortName   = "name"
+)
+
+// topStatsTimeout bounds one `docker stats --no-stream` sample.
+const topStatsTimeout = 5 * time.Second
+
+type topStatsMsg struct {
+	resources []status.ContainerResource
+	at        time.Time
+}
+
+type topTickMsg struct{}
+
+// TopModel renders docker stats for the project's containers, refreshed on an interval.
+type TopModel struct {
+	envFile   string
+	interval  time.Duration
+	sortBy    string
+	theme     Theme
+	resources []status.ContainerResource
+	updated   time.Time
+	loading   bool
+	width     int
+}
+
+// ValidateTopSort checks a --sort value for the top view.
+func ValidateTopSort(sortBy string) error {
+	switch sortBy {
+	case TopSortCPU, TopSortMemory, TopSortName:
+		return nil
+	}
+	return fmt.Errorf("invalid sort %q (expected %s, %s or %s)", sortBy, TopSortCPU, TopSortMemory, TopSortName)
+}
+
+// RunTop starts the full-screen resource view until the user quits.
+func RunTop(ctx context.Context, envFile string, interval time.Duration, sortBy, themeName string) error {
+	resolvedEnv, err := internal.ResolveEnvFilePath(envFile)
+	if err != nil {
+		return err
+	}
+	theme, _ := loadTheme(themeName)
+	model := &TopModel{envFile: resolvedEnv, interval: interval, sortBy: sortBy, theme: theme, loading: true}
+
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if ctx != nil {
+		options = append(options, tea.WithContext(ctx))
+	}
+	_, err = tea.NewProgram(model, options...).Run()
+	return err
+}
+
+func (m *TopModel) Init() tea.Cmd {
+	return fetchTopStatsCmd(m.envFile)
+}
+
+func fetchTopStatsCmd(envFile string) tea.Cmd {
+	return func() tea.Msg {
+		return topStatsMsg{resources: status.CollectContainerResources(envFile, topStatsTimeout), at: time.Now()}
+	}
+}
+
+func (m *TopModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+	case topStatsMsg:
+		m.resources = msg.resources
+		m.updated = msg.at
+		m.loading = false
+		// Schedule the next sample once this one is in, so slow samples never pile up
+		return m, tea.Tick(m.interval, func(time.Time) tea.Msg { return topTickMsg{} })
+	case topTickMsg:
+		return m, fetchTopStatsCmd(m.envFile)
+	case tea.KeyMsg:
+		switch strings.ToLower(msg.String()) {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "c":
+			m.sortBy = TopSortCPU
+		case "m":
+			m.sortBy = TopSortMemory
+		case "n":
+			m.sortBy = TopSortName
+		}
+	}
+	return m, nil
+}
+
+// sorted returns the resources in the selected order, heaviest first for CPU and memory.
+func (m *TopModel) sorted() []status.ContainerResource {
+	rows := append([]status.ContainerResource(nil), m.resources...)
+	sort.SliceStable(rows, func(i, j int) bool {
+		switch m.sortBy {
+		case TopSortCPU:
+			return rows[i].CPUPercent > rows[j].CPUPercent
+		case TopSortMemory:
+			return rows[i].MemUsageBytes > rows[j].MemUsageBytes
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
+
+func (m *TopModel) View() string {
+	var parts []string
+	parts = append(parts, m.theme.Title.Render(" leyzenctl top "))
+
+	switch {
+	case m.loading:
+		parts = append(parts, m.theme.Subtitle.Render("Sampling docker stats..."))
+	case len(m.resources) == 0:
+		parts = append(parts, m.theme.Pane.Render("No running containers (or docker stats is unavailable)."))
+	default:
+		headers := []string{"CONTAINER", "CPU %", "MEMORY", "MEM %"}
+		sortColumn := map[string]int{TopSortName: 0, TopSortCPU: 1, TopSortMemory: 2}[m.sortBy]
+		headers[sortColumn] += " ▼"
+
+		t := table.New().
+			Border(lipgloss.RoundedBorder()).
+			BorderStyle(m.theme.HelpDesc).
+			Headers(headers...).
+			StyleFunc(func(row, col int) lipgloss.Style {
+				style := lipgloss.NewStyle().Padding(0, 1)
+				if col > 0 {
+					style = style.Align(lipgloss.Right)
+				}
+				if row == 0 {
+					return style.Inherit(m.theme.Accent)
+				}
+				return style
+			})
+		for _, r := range m.sorted() {
+			t.Row(
+				r.Name,
+				fmt.Sprintf("%.1f%%", r.CPUPercent),
+				fmt.Sprintf("%.1f MiB / %.1f MiB", float64(r.MemUsageBytes)/(1<<20), float64(r.MemLimitBytes)/(1<<20)),
+				fmt.Sprintf("%.1f%%", r.MemPercent),
+			)
+		}
+		parts = append(parts, t.Render())
+	}
+
+	if !m.updated.IsZero() {
+		parts = append(parts, m.theme.Subtitle.Render(fmt.Sprintf("Updated %s · every %s", m.updated.Format("15:04:05"), m.interval)))
+	}
+	parts = append(parts, renderHintsFooter(m.theme, bindingsFor(contextTop)))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -12,26 +13,47 @@ import (
 	"leyzenctl/internal"
 )
 
+// statusMsg carries the statuses of envFile. A reply for another file arrives when the
+// env file was switched while it was in flight, and is dropped.
 type statusMsg struct {
+	envFile  string
 	statuses []ContainerStatus
 	err      error
 }
 
+type containerDetailMsg struct {
+	service string
+	detail  internal.ContainerDetail
+	err     error
+}
+
+type crashLogsMsg struct {
+	name  string
+	lines []string
+}
+
 type statusTickMsg struct{}
 
 type actionProgressMsg struct {
 	Action  ActionType
 	Line    string
 	LineRaw string // Raw line before cleaning/filtering
+	Step    int    // Build step announced by the line, with Steps the total; 0 when none
+	Steps   int
 	Err     error
 	Done    bool
 }
 
 type successTimeoutMsg struct{}
 
+// configListMsg carries the configuration of envFile; like statusMsg, replies for a
+// previous env file are dropped.
 type configListMsg struct {
-	pairs map[string]string
-	err   error
+	envFile  string
+	pairs    map[string]string
+	docs     map[string]internal.EnvDoc
+	defaults map[string]string
+	err      error
 }
 
 type composeServicesMsg struct {
@@ -40,19 +62,38 @@ type composeServicesMsg struct {
 	err      error
 }
 
+// Update captures the mouse only while the container selection view is shown, so the
+// terminal's own text selection keeps working everywhere else.
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	before := m.viewState
+	model, cmd := m.update(msg)
+	switch {
+	case m.viewState == before:
+	case m.viewState == ViewContainerSelection:
+		cmd = tea.Batch(cmd, tea.EnableMouseCellMotion)
+	case before == ViewContainerSelection:
+		cmd = tea.Batch(cmd, tea.DisableMouse)
+	}
+	return model, cmd
+}
+
+func (m *Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		return m.handleWindowSize(msg)
 	case statusMsg:
 		return m.handleStatus(msg)
 	case statusTickMsg:
+		if m.pollingPaused {
+			// Keep ticking so resuming needs no new schedule, but skip the docker calls
+			return m, scheduleStatusRefresh(m.refreshInterval)
+		}
 		if m.actionRunning {
 			// Delay refresh until the action completes.
 			m.pendingRefresh = true
-			return m, scheduleStatusRefresh()
+			return m, scheduleStatusRefresh(m.refreshInterval)
 		}
-		return m, tea.Batch(fetchStatusesCmd(m.envFile), scheduleStatusRefresh())
+		return m, tea.Batch(fetchStatusesCmd(m.envFile), scheduleStatusRefresh(m.statusRefreshDelay()))
 	case tea.KeyMsg:
 		// CTRL+C confirmed: quit
 		if msg.String() == "ctrl+c" {
@@ -72,6 +113,9 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.quitConfirm = false
 		}
 
+		if m.viewState == ViewLogs && m.logsSinceInput {
+			return m.handleLogsSinceKey(msg)
+		}
 		if m.viewState == ViewConfig {
 			keyStr := msg.String()
 			if keyStr == "up" || keyStr == "down" || keyStr == "pgup" || keyStr == "pgdn" {
@@ -83,30 +127,70 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.viewState == ViewContainerSelection {
 			return m.handleContainerSelectionKey(msg)
 		}
+		if m.viewState == ViewEnvPicker {
+			return m.handleEnvPickerKey(msg)
+		}
+		if m.confirmAction != ActionNone {
+			return m.handleActionConfirmKey(msg)
+		}
+		if m.helpVisible && m.viewState == ViewDashboard {
+			return m.handleHelpKey(msg)
+		}
 		return m.handleKey(msg)
 	case actionProgressMsg:
 		return m.handleActionProgress(msg)
+	case containerDetailMsg:
+		if m.viewState == ViewContainerDetail && msg.service == m.containerDetailName {
+			m.containerDetailErr = msg.err
+			if msg.err == nil {
+				m.containerDetail = &msg.detail
+			}
+		}
+		return m, nil
+	case crashLogsMsg:
+		if m.isCrashLooping(msg.name) {
+			m.crashLogs[msg.name] = msg.lines
+		}
+		return m, nil
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 	case successTimeoutMsg:
 		m.successMessage = ""
+		m.warningMessage = ""
 		if m.successTimer != nil {
 			m.successTimer = nil
 		}
 		return m, nil
 	case configListMsg:
+		if msg.envFile != m.envFile {
+			return m, nil
+		}
 		if msg.err != nil {
+			m.configReload = false
 			errMsg := fmt.Sprintf("[ERROR] failed to load config: %v", msg.err)
 			m.appendLog(errMsg, errMsg)
 			return m, nil
 		}
 		m.configPairs = msg.pairs
+		m.configDocs = msg.docs
+		m.configDefaults = msg.defaults
+		if m.configReload {
+			m.configReload = false
+			return m, nil
+		}
 		if m.viewState == ViewDashboard && len(m.wizardFields) == 0 {
 			m.initWizard(msg.pairs)
+			if len(m.setupIssues) > 0 {
+				m.wizardError = "Setup required: " + strings.Join(m.setupIssues, "; ")
+			}
 		}
 		return m, nil
+	case tea.MouseMsg:
+		if m.viewState == ViewContainerSelection {
+			return m.handleContainerSelectionMouse(msg)
+		}
 	case wizardSaveMsg:
 		return m.handleWizardSave(msg)
 	case composeServicesMsg:
@@ -129,6 +213,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	if m.viewState == ViewEnvPicker {
+		var cmd tea.Cmd
+		m.envPicker, cmd = m.envPicker.Update(msg)
+		return m, cmd
+	}
+
 	if m.viewState == ViewWizard && len(m.wizardFields) > 0 {
 		if m.wizardIndex < len(m.wizardFields) {
 			var cmd tea.Cmd
@@ -178,6 +268,14 @@ func (m *Model) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 			}
 			m.viewport.Height = viewportHeight
 		}
+		// Lines are wrapped or cut at the viewport width, so reflow them for the new size
+		if m.viewState == ViewLogs || m.viewState == ViewAction {
+			m.setLogContent(m.displayedLogs())
+		}
+	}
+
+	if m.viewState == ViewEnvPicker {
+		m.envPicker.Height = m.envPickerHeight()
 	}
 
 	if m.viewState == ViewContainerSelection {
@@ -195,16 +293,27 @@ func (m *Model) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) handleStatus(msg statusMsg) (tea.Model, tea.Cmd) {
+	if msg.envFile != m.envFile {
+		return m, nil
+	}
 	if msg.err != nil {
 		errMsg := fmt.Sprintf("[ERROR] status refresh failed: %v", msg.err)
 		m.appendLog(errMsg, errMsg)
 		return m, nil
 	}
+	if statusesChanged(m.statuses, msg.statuses) {
+		m.markStatusActivity()
+		m.recordTransitions(m.statuses, msg.statuses)
+	}
+	cmds := m.trackRestarts(msg.statuses)
 	m.statuses = msg.statuses
+	if m.statusCursor >= len(m.statuses) {
+		m.statusCursor = max(len(m.statuses)-1, 0)
+	}
 	if m.pendingRefresh {
 		m.pendingRefresh = false
 	}
-	return m, nil
+	return m, tea.Batch(cmds...)
 }
 
 func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -227,7 +336,14 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.quitConfirm = true
 		return m, nil
 	case "esc":
-		if m.viewState == ViewLogs || m.viewState == ViewConfig || m.viewState == ViewWizard {
+		if m.viewState == ViewLogs && m.action == ActionLogs && m.actionStream != nil {
+			// Let the log fetch finish in the background instead of blocking on the stream
+			go func(stream <-chan actionProgressMsg) {
+				for range stream {
+				}
+			}(m.actionStream)
+		}
+		if m.viewState == ViewLogs || m.viewState == ViewConfig || m.viewState == ViewWizard || m.viewState == ViewContainerDetail {
 			m.switchToDashboard()
 			return m, nil
 		}
@@ -236,19 +352,66 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		return m, nil
+	case "enter":
+		if m.viewState == ViewDashboard {
+			return m, m.switchToContainerDetail()
+		}
+		return m, nil
 	case "r":
 		if m.viewState == ViewConfig {
 			return m, fetchConfigListCmd(m.envFile)
 		}
+		if m.viewState == ViewContainerDetail {
+			return m, fetchContainerDetailCmd(m.envFile, m.containerDetailName)
+		}
 		if m.viewState == ViewDashboard {
 			return m, fetchComposeServicesCmd(m.envFile, ActionRestart)
 		}
 		return m, nil
+	case "ctrl+r":
+		// Restart only the highlighted service, skipping the container selection
+		if m.viewState == ViewDashboard && m.statusCursor < len(m.statuses) {
+			return m.startActionWithServices(ActionRestart, []string{m.statuses[m.statusCursor].Name})
+		}
+		return m, nil
 	case "?":
 		if m.viewState == ViewDashboard {
 			m.helpVisible = !m.helpVisible
 		}
 		return m, nil
+	case "t":
+		if m.viewState == ViewDashboard {
+			m.transitionsVisible = !m.transitionsVisible
+		}
+		if m.viewState == ViewLogs && !m.actionRunning {
+			m.logsSinceInput = true
+			m.logsSinceQuery = m.logsSince
+		}
+		return m, nil
+	case "x":
+		if m.viewState == ViewDashboard {
+			m.crashDetailsVisible = !m.crashDetailsVisible
+		}
+		return m, nil
+	case "p":
+		if m.viewState == ViewDashboard {
+			m.pollingPaused = !m.pollingPaused
+			if !m.pollingPaused {
+				m.markStatusActivity()
+				return m, fetchStatusesCmd(m.envFile)
+			}
+		}
+		return m, nil
+	case "f":
+		if m.viewState == ViewDashboard {
+			return m, fetchStatusesCmd(m.envFile)
+		}
+		return m, nil
+	case "e":
+		if m.viewState == ViewDashboard {
+			return m, m.openEnvPicker()
+		}
+		return m, nil
 	case "l":
 		if m.viewState == ViewDashboard {
 			m.switchToLogs()
@@ -262,13 +425,13 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case " ":
+		if m.viewState == ViewLogs || m.viewState == ViewAction {
+			m.toggleScrollLock()
+			return m, nil
+		}
 		if m.viewState == ViewConfig {
 			for key := range m.configPairs {
-				keyLower := strings.ToLower(key)
-				if strings.Contains(keyLower, "password") ||
-					strings.Contains(keyLower, "secret") ||
-					strings.Contains(keyLower, "pass") ||
-					strings.Contains(keyLower, "token") {
+				if internal.IsSecretKey(key) {
 					m.configShowPasswords[key] = !m.configShowPasswords[key]
 				}
 			}
@@ -276,6 +439,10 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case "w":
+		if m.viewState == ViewLogs || m.viewState == ViewAction {
+			m.toggleLogWrap()
+			return m, nil
+		}
 		if m.viewState == ViewDashboard {
 			if len(m.configPairs) == 0 {
 				return m, fetchConfigListCmd(m.envFile)
@@ -327,7 +494,7 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-			m.viewport.SetContent(strings.Join(logsToDisplay, "\n"))
+			m.setLogContent(logsToDisplay)
 
 			if m.logModeRaw {
 				if m.viewportYOffsetRaw > 0 {
@@ -347,6 +514,15 @@ func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case "up", "down", "pgup", "pgdn", "home", "end":
+		if m.viewState == ViewDashboard {
+			if keyStr == "up" && m.statusCursor > 0 {
+				m.statusCursor--
+			}
+			if keyStr == "down" && m.statusCursor < len(m.statuses)-1 {
+				m.statusCursor++
+			}
+			return m, nil
+		}
 		// Navigation in viewport for logs/action/config views
 		if m.viewState == ViewLogs || m.viewState == ViewAction || m.viewState == ViewConfig {
 			var cmd tea.Cmd
@@ -376,28 +552,7 @@ func (m *Model) handleContainerSelectionKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 		return m, nil
 	case " ":
 		// Toggle selection
-		if len(m.containerItems) == 0 {
-			return m, nil
-		}
-		idx := m.containerIndex
-		if idx < 0 || idx >= len(m.containerItems) {
-			return m, nil
-		}
-
-		item := &m.containerItems[idx]
-		item.Selected = !item.Selected
-
-		if item.IsAllOption && item.Selected {
-			for i := range m.containerItems {
-				if i != idx {
-					m.containerItems[i].Selected = false
-				}
-			}
-		} else if item.Selected {
-			if len(m.containerItems) > 0 && m.containerItems[0].IsAllOption {
-				m.containerItems[0].Selected = false
-			}
-		}
+		m.toggleContainerItem(m.containerIndex)
 		return m, nil
 	case "enter":
 		// Confirm selection and execute action
@@ -424,6 +579,17 @@ func (m *Model) handleContainerSelectionKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 		// Save pending action before any state changes
 		pendingAction := m.pendingAction
 
+		// Stopping the whole stack runs `down --remove-orphans`, so ask before doing it
+		if pendingAction == ActionStop && len(selectedServices) == 0 {
+			m.containerItems = nil
+			m.containerIndex = 0
+			m.availableServices = nil
+			m.pendingAction = ActionNone
+			m.viewState = ViewDashboard
+			m.confirmAction = ActionStop
+			return m, nil
+		}
+
 		// Clean up container selection view state without calling switchToDashboard
 		// (which would reset pendingAction)
 		m.containerItems = nil
@@ -453,6 +619,221 @@ func (m *Model) handleContainerSelectionKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 	}
 }
 
+// handleWizardJumpKey edits the wizard field filter. Enter jumps to the highlighted
+// field; Esc closes the filter and stays on the current field.
+func (m *Model) handleWizardJumpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	matches := m.wizardJumpMatches()
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.wizardJumpActive = false
+		return m, nil
+	case tea.KeyEnter:
+		if m.wizardJumpCursor < len(matches) {
+			m.focusWizardField(matches[m.wizardJumpCursor])
+		}
+		m.wizardJumpActive = false
+		return m, nil
+	case tea.KeyUp:
+		if m.wizardJumpCursor > 0 {
+			m.wizardJumpCursor--
+		}
+		return m, nil
+	case tea.KeyDown:
+		if m.wizardJumpCursor < len(matches)-1 {
+			m.wizardJumpCursor++
+		}
+		return m, nil
+	case tea.KeyBackspace:
+		if runes := []rune(m.wizardJumpQuery); len(runes) > 0 {
+			m.wizardJumpQuery = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes:
+		m.wizardJumpQuery += string(msg.Runes)
+	default:
+		return m, nil
+	}
+	m.wizardJumpCursor = 0
+	return m, nil
+}
+
+// handleHelpKey drives the cheatsheet: / starts a search, Esc clears it or closes
+// the overlay, and ? closes it.
+func (m *Model) handleHelpKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.helpSearching {
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.helpSearching = false
+			m.helpQuery = ""
+		case tea.KeyEnter:
+			m.helpSearching = false
+		case tea.KeyBackspace:
+			if runes := []rune(m.helpQuery); len(runes) > 0 {
+				m.helpQuery = string(runes[:len(runes)-1])
+			}
+		case tea.KeySpace:
+			m.helpQuery += " "
+		case tea.KeyRunes:
+			m.helpQuery += string(msg.Runes)
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "/":
+		m.helpSearching = true
+	case "esc":
+		if m.helpQuery != "" {
+			m.helpQuery = ""
+			return m, nil
+		}
+		m.helpVisible = false
+	case "?":
+		m.helpVisible = false
+		m.helpQuery = ""
+	}
+	return m, nil
+}
+
+// handleLogsSinceKey edits the --since window of the logs view. Enter fetches the
+// container logs for that window; an empty window returns to the action history.
+func (m *Model) handleLogsSinceKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.logsSinceInput = false
+		m.logsSinceQuery = ""
+	case tea.KeyEnter:
+		since := strings.TrimSpace(m.logsSinceQuery)
+		if since != "" {
+			if d, err := time.ParseDuration(since); err != nil || d <= 0 {
+				// Keep the input open so the value can be corrected
+				return m, nil
+			}
+		}
+		m.logsSinceInput = false
+		m.logsSinceQuery = ""
+		return m.applyLogsSince(since)
+	case tea.KeyBackspace:
+		if runes := []rune(m.logsSinceQuery); len(runes) > 0 {
+			m.logsSinceQuery = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes:
+		m.logsSinceQuery += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// applyLogsSince shows the container logs of the window in the logs view. The action
+// history is set aside and comes back when the window is cleared or the view is left.
+func (m *Model) applyLogsSince(since string) (tea.Model, tea.Cmd) {
+	if since == "" {
+		m.clearLogsSince()
+		m.setLogContent(m.displayedLogs())
+		m.viewport.GotoBottom()
+		return m, nil
+	}
+
+	stream, err := m.runner.Logs(since)
+	if err != nil {
+		errMsg := color.HiRedString(fmt.Sprintf("[ERROR] failed to fetch logs: %v", err))
+		m.appendLog(errMsg, errMsg)
+		return m, nil
+	}
+
+	m.stopLogsFetch()
+	if m.logsSince == "" {
+		m.logsHistory, m.logsRawHistory = m.logs, m.logsRaw
+	}
+	m.logsSince = since
+	m.logs = nil
+	m.logsRaw = nil
+	m.viewport.SetContent("")
+	m.viewport.GotoTop()
+	m.viewportYOffsetNormal = 0
+	m.viewportYOffsetRaw = 0
+	m.actionStream = stream
+	m.action = ActionLogs
+	m.actionRunning = true
+	return m, tea.Batch(waitForActionProgress(stream), m.spinner.Tick)
+}
+
+// clearLogsSince drops the --since window, if any, and puts the action history back.
+func (m *Model) clearLogsSince() {
+	if m.logsSince == "" {
+		return
+	}
+	m.stopLogsFetch()
+	m.logsSince = ""
+	m.logs, m.logsRaw = m.logsHistory, m.logsRawHistory
+	m.logsHistory, m.logsRawHistory = nil, nil
+	m.viewportYOffsetNormal = 0
+	m.viewportYOffsetRaw = 0
+}
+
+// stopLogsFetch detaches a running --since fetch so its lines no longer reach the view.
+func (m *Model) stopLogsFetch() {
+	if m.action != ActionLogs || m.actionStream == nil {
+		return
+	}
+	go func(stream <-chan actionProgressMsg) {
+		for range stream {
+		}
+	}(m.actionStream)
+	m.actionStream = nil
+	m.action = ActionNone
+	m.actionRunning = false
+}
+
+// handleActionConfirmKey runs the action awaiting confirmation when y, enter or the
+// action's own key is pressed; any other key cancels it.
+func (m *Model) handleActionConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action := m.confirmAction
+	m.confirmAction = ActionNone
+
+	switch strings.ToLower(msg.String()) {
+	case "y", "enter", "s":
+		return m.startActionWithServices(action, []string{})
+	}
+	return m, nil
+}
+
+// handleContainerSelectionMouse toggles the row under a left click.
+func (m *Model) handleContainerSelectionMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+	idx := msg.Y - m.containerListTop()
+	if idx < 0 || idx >= len(m.containerItems) {
+		return m, nil
+	}
+	m.containerIndex = idx
+	m.toggleContainerItem(idx)
+	return m, nil
+}
+
+// toggleContainerItem flips the selection of an item. Selecting "All" clears the
+// other items, and selecting a service clears "All".
+func (m *Model) toggleContainerItem(idx int) {
+	if idx < 0 || idx >= len(m.containerItems) {
+		return
+	}
+
+	item := &m.containerItems[idx]
+	item.Selected = !item.Selected
+
+	if item.IsAllOption && item.Selected {
+		for i := range m.containerItems {
+			if i != idx {
+				m.containerItems[i].Selected = false
+			}
+		}
+	} else if item.Selected {
+		if m.containerItems[0].IsAllOption {
+			m.containerItems[0].Selected = false
+		}
+	}
+}
+
 func (m *Model) startAction(action ActionType) (tea.Model, tea.Cmd) {
 	return m.startActionWithServices(action, []string{})
 }
@@ -482,6 +863,8 @@ func (m *Model) startActionWithServices(action ActionType, services []string) (t
 	m.actionStream = stream
 	m.action = action
 	m.actionRunning = true
+	m.buildStep, m.buildSteps = 0, 0
+	m.markStatusActivity()
 	m.switchToAction()
 
 	return m, tea.Batch(waitForActionProgress(stream), m.spinner.Tick)
@@ -500,18 +883,48 @@ func (m *Model) handleActionProgress(msg actionProgressMsg) (tea.Model, tea.Cmd)
 		}
 		m.appendLog(msg.Line, lineRaw)
 	}
+	if msg.Steps > 0 {
+		m.buildStep, m.buildSteps = msg.Step, msg.Steps
+	}
 
 	if msg.Err != nil {
 		actionName := string(msg.Action)
-		errMsg := color.HiRedString(fmt.Sprintf("[ERROR] %s failed: %v", actionName, msg.Err))
-		m.appendLog(errMsg, errMsg)
+		var buildErr *internal.BuildConfigError
+		if errors.As(msg.Err, &buildErr) && buildErr.MissingVariable() != "" {
+			name := buildErr.MissingVariable()
+			banner := color.HiRedString(fmt.Sprintf("[CONFIG INCOMPLETE] configuration incomplete: set %s", name))
+			hint := color.HiYellowString(fmt.Sprintf("%s stopped before docker compose ran. Set %s in the configuration wizard (w) and retry.", actionName, name))
+			m.appendLog(banner, banner)
+			m.appendLog(hint, hint)
+		} else if errors.As(msg.Err, &buildErr) {
+			// Generation stops before compose runs, so the stack still uses the previous files
+			banner := color.HiRedString(fmt.Sprintf("[CONFIG GENERATION FAILED] %v", buildErr.Err))
+			hint := color.HiYellowString(fmt.Sprintf("%s stopped before docker compose ran; docker-generated.yml was not updated. Run 'leyzenctl config validate' to check the env file.", actionName))
+			m.appendLog(banner, banner)
+			m.appendLog(hint, hint)
+		} else {
+			errMsg := color.HiRedString(fmt.Sprintf("[ERROR] %s failed: %v", actionName, msg.Err))
+			m.appendLog(errMsg, errMsg)
+		}
 		m.actionRunning = false
 		m.action = ActionNone
 		m.actionStream = nil
+		m.markStatusActivity()
 
 		return m, fetchStatusesCmd(m.envFile)
 	}
 
+	if msg.Done && msg.Action == ActionLogs {
+		// Log fetches stay in the logs view
+		m.actionRunning = false
+		m.action = ActionNone
+		m.actionStream = nil
+		if len(m.logs) == 0 {
+			m.appendLog(fmt.Sprintf("No log output in the last %s", m.logsSince), "")
+		}
+		return m, nil
+	}
+
 	if msg.Done {
 		actionName := string(msg.Action)
 		doneMsg := color.HiGreenString(fmt.Sprintf("%s completed", actionName))
@@ -519,6 +932,7 @@ func (m *Model) handleActionProgress(msg actionProgressMsg) (tea.Model, tea.Cmd)
 		m.actionRunning = false
 		m.action = ActionNone
 		m.actionStream = nil
+		m.markStatusActivity()
 
 		m.successMessage = fmt.Sprintf("%s completed successfully", actionName)
 
@@ -539,9 +953,48 @@ func (m *Model) handleActionProgress(msg actionProgressMsg) (tea.Model, tea.Cmd)
 }
 
 func (m *Model) handleWizardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.wizardConfirm {
+		return m.handleWizardConfirmKey(msg)
+	}
+	if m.wizardJumpActive {
+		return m.handleWizardJumpKey(msg)
+	}
+
 	key := msg.String()
 
 	switch key {
+	case "pgdown":
+		m.focusWizardField(m.nextWizardCategory(1))
+		return m, nil
+	case "pgup":
+		m.focusWizardField(m.nextWizardCategory(-1))
+		return m, nil
+	case "ctrl+g":
+		field := &m.wizardFields[m.wizardIndex]
+		if !field.IsPassword {
+			m.wizardError = fmt.Sprintf("%s is not a secret; Ctrl+G only generates secrets", field.Key)
+			return m, nil
+		}
+		secret, err := internal.GenerateSecret()
+		if err != nil {
+			m.wizardError = err.Error()
+			return m, nil
+		}
+		field.Input.SetValue(secret)
+		field.Input.CursorEnd()
+		m.wizardError = ""
+		return m, nil
+	case "ctrl+r":
+		field := &m.wizardFields[m.wizardIndex]
+		field.Input.SetValue(field.Default)
+		field.Input.CursorEnd()
+		m.wizardError = ""
+		return m, nil
+	case "ctrl+f":
+		m.wizardJumpActive = true
+		m.wizardJumpQuery = ""
+		m.wizardJumpCursor = 0
+		return m, nil
 	case "ctrl+s", "ctrl+S":
 		return m.saveWizard()
 	case "esc":
@@ -606,7 +1059,7 @@ func (m *Model) validateWizardField(index int, value string) error {
 		return nil
 	}
 
-	_, err := internal.ValidateEnvValue(field.Key, value)
+	_, err := internal.ValidateEnvValue(m.envFile, field.Key, value)
 	if err != nil {
 		return err
 	}
@@ -614,23 +1067,39 @@ func (m *Model) validateWizardField(index int, value string) error {
 	return nil
 }
 
+// saveWizard validates every field and opens the change summary for confirmation.
 func (m *Model) saveWizard() (tea.Model, tea.Cmd) {
-	hasErrors := false
 	for i := range m.wizardFields {
 		value := m.wizardFields[i].Input.Value()
 		if err := m.validateWizardField(i, value); err != nil {
 			m.wizardError = fmt.Sprintf("Error in %s: %v", m.wizardFields[i].Key, err)
-			hasErrors = true
-			break
+			return m, nil
 		}
-		m.wizardFields[i].Value = value
 	}
 
-	if hasErrors {
-		return m, nil
+	m.wizardError = ""
+	m.wizardConfirm = true
+	return m, nil
+}
+
+// handleWizardConfirmKey saves on Enter or y and returns to editing on Esc or n.
+func (m *Model) handleWizardConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch strings.ToLower(msg.String()) {
+	case "enter", "y":
+		m.wizardConfirm = false
+		return m.commitWizard()
+	case "esc", "n":
+		m.wizardConfirm = false
+	}
+	return m, nil
+}
+
+// commitWizard writes the wizard values to the env file.
+func (m *Model) commitWizard() (tea.Model, tea.Cmd) {
+	for i := range m.wizardFields {
+		m.wizardFields[i].Value = m.wizardFields[i].Input.Value()
 	}
 
-	m.wizardError = ""
 	m.switchToAction()
 	m.action = ActionWizard
 	m.actionRunning = true
@@ -642,7 +1111,8 @@ func (m *Model) saveWizard() (tea.Model, tea.Cmd) {
 }
 
 type wizardSaveMsg struct {
-	err error
+	err    error
+	dryRun bool // --dry-run: the changes were validated but not written
 }
 
 func saveWizardCmd(envFile string, fields []WizardField) tea.Cmd {
@@ -657,22 +1127,19 @@ func saveWizard(envFile string, fields []WizardField) tea.Msg {
 		return wizardSaveMsg{err: fmt.Errorf("failed to load env file: %w", err)}
 	}
 
-	for _, field := range fields {
-		value := field.Value
-		sanitized := strings.TrimSpace(value)
+	if err := applyWizardFields(envFileObj, fields); err != nil {
+		return wizardSaveMsg{err: err}
+	}
 
-		if sanitized != "" {
-			validated, err := internal.ValidateEnvValue(field.Key, sanitized)
-			if err != nil {
-				return wizardSaveMsg{err: fmt.Errorf("%s: %w", field.Key, err)}
-			}
-			sanitized = validated
-		}
+	if err := internal.ValidateSizeLimits(envFileObj.Pairs()); err != nil {
+		return wizardSaveMsg{err: err}
+	}
 
-		envFileObj.Set(field.Key, sanitized)
+	if internal.DryRun() {
+		return wizardSaveMsg{dryRun: true}
 	}
 
-	if err := envFileObj.Write(); err != nil {
+	if err := envFileObj.WriteWithBackup(); err != nil {
 		return wizardSaveMsg{err: fmt.Errorf("failed to write env file: %w", err)}
 	}
 
@@ -684,9 +1151,33 @@ func saveWizard(envFile string, fields []WizardField) tea.Msg {
 	return wizardSaveMsg{err: nil}
 }
 
+// applyWizardFields sets the wizard values on envFileObj. Leaving a field empty keeps the
+// existing value; only absent keys are added blank.
+func applyWizardFields(envFileObj *internal.EnvFile, fields []WizardField) error {
+	for _, field := range fields {
+		value := field.Value
+		sanitized := strings.TrimSpace(value)
+
+		if sanitized == "" {
+			if _, exists := envFileObj.Get(field.Key); !exists {
+				envFileObj.Set(field.Key, "")
+			}
+			continue
+		}
+
+		validated, err := internal.ValidateEnvValue(envFileObj.Path, field.Key, sanitized)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field.Key, err)
+		}
+		envFileObj.Set(field.Key, validated)
+	}
+	return nil
+}
+
 func (m *Model) handleWizardSave(msg wizardSaveMsg) (tea.Model, tea.Cmd) {
 	m.actionRunning = false
 	m.action = ActionNone
+	m.markStatusActivity()
 
 	m.switchToDashboard()
 
@@ -696,6 +1187,12 @@ func (m *Model) handleWizardSave(msg wizardSaveMsg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	if msg.dryRun {
+		m.warningMessage = "[dry-run] Configuration validated but not saved"
+		return m, tea.Tick(successMessageDuration, func(time.Time) tea.Msg { return successTimeoutMsg{} })
+	}
+
+	m.setupIssues = nil
 	m.successMessage = "Configuration saved successfully"
 
 	cmd := tea.Sequence(
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"leyzenctl/internal"
+)
+
+func TestApplyWizardFieldsKeepsValueOfEmptiedField(t *testing.T) {
+	envPath := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(envPath, []byte("HTTP_PORT=8080\nTIMEZONE=UTC\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	envFile, err := internal.LoadEnvFile(envPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := []WizardField{
+		{Key: "HTTP_PORT", Value: ""},
+		{Key: "TIMEZONE", Value: "Europe/Paris"},
+		{Key: "VAULT_URL", Value: "  "},
+	}
+	if err := applyWizardFields(envFile, fields); err != nil {
+		t.Fatalf("applyWizardFields: %v", err)
+	}
+	if err := envFile.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := internal.LoadEnvFile(envPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"HTTP_PORT": "8080", "TIMEZONE": "Europe/Paris", "VAULT_URL": ""}
+	for key, value := range want {
+		got, ok := reloaded.Get(key)
+		if !ok || got != value {
+			t.Errorf("%s = %q (present %v), want %q", key, got, ok, value)
+		}
+	}
+}
+
+func TestWizardFieldChangedIgnoresEmptyInput(t *testing.T) {
+	field := WizardField{Key: "HTTP_PORT", Value: "8080"}
+	if field.Changed() {
+		t.Error("empty input reported as a change")
+	}
+	field.Input.SetValue("9090")
+	if !field.Changed() {
+		t.Error("new input not reported as a change")
+	}
+}
+
+func TestRepliesForPreviousEnvFileAreDropped(t *testing.T) {
+	chdirRepo(t)
+	m := NewModel("old.env", NewRunner("old.env"), "dark")
+	m.statuses = []ContainerStatus{{Name: "postgres", Status: "running"}}
+
+	// What switchEnvFile leaves behind before the new replies arrive
+	m.envFile = "new.env"
+	m.configReload = true
+
+	m.Update(statusMsg{envFile: "old.env", statuses: []ContainerStatus{{Name: "haproxy", Status: "exited"}}})
+	if len(m.statuses) != 1 || m.statuses[0].Name != "postgres" {
+		t.Errorf("statuses = %v, want the stale reply dropped", m.statuses)
+	}
+	m.Update(configListMsg{envFile: "old.env", pairs: map[string]string{"HTTP_PORT": "8080"}})
+	if _, applied := m.configPairs["HTTP_PORT"]; applied || !m.configReload {
+		t.Errorf("stale config reply applied: pairs = %v, configReload = %v", m.configPairs, m.configReload)
+	}
+
+	// A failed reload for the current file must not leave the reload flag set
+	m.Update(configListMsg{envFile: "new.env", err: os.ErrNotExist})
+	if m.configReload {
+		t.Error("configReload still set after the reload failed")
+	}
+}
+
+func TestLogsSinceWindowKeepsActionHistory(t *testing.T) {
+	chdirRepo(t)
+	// The fetch only prints its plan, so no docker is needed
+	internal.SetDryRun(true)
+	t.Cleanup(func() { internal.SetDryRun(false) })
+
+	m := NewModel(".env", NewRunner(".env"), "dark")
+	m.switchToLogs()
+	m.logs = []string{"restart completed"}
+	m.logsRaw = []string{"restart completed (raw)"}
+
+	m.applyLogsSince("10m")
+	m.Update(actionProgressMsg{Action: ActionLogs, Line: "vault_web1 | started"})
+	if len(m.logs) != 1 || m.logs[0] != "vault_web1 | started" {
+		t.Fatalf("logs = %v, want only the container logs of the window", m.logs)
+	}
+
+	// Clearing the window brings the history back
+	m.applyLogsSince("")
+	if m.logsSince != "" || len(m.logs) != 1 || m.logs[0] != "restart completed" || m.logsRaw[0] != "restart completed (raw)" {
+		t.Fatalf("after clearing the window: since = %q, logs = %v, raw = %v", m.logsSince, m.logs, m.logsRaw)
+	}
+
+	// Leaving the view saves the history, not the window, and resets the window
+	m.applyLogsSince("1h")
+	m.Update(actionProgressMsg{Action: ActionLogs, Line: "postgres | ready"})
+	m.switchToDashboard()
+	if m.logsSince != "" {
+		t.Errorf("logsSince = %q after leaving the view, want it reset", m.logsSince)
+	}
+	if len(m.logsBuffer) != 1 || m.logsBuffer[0] != "restart completed" {
+		t.Errorf("logsBuffer = %v, want the action history", m.logsBuffer)
+	}
+}
+
+func TestSwitchEnvFileFollowsComposeProjectName(t *testing.T) {
+	dir := chdirRepo(t)
+	t.Setenv("COMPOSE_PROJECT_NAME", "")
+	t.Cleanup(func() { internal.SetComposeProjectName("") })
+	staging := filepath.Join(dir, "staging.env")
+	if err := os.WriteFile(staging, []byte("COMPOSE_PROJECT_NAME=staging\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(".env", NewRunner(".env"), "dark")
+	m.switchEnvFile(staging)
+	if got := internal.ScopedName("vault_app"); got != "staging-vault_app" {
+		t.Errorf("after switching to staging.env: ScopedName = %q, want staging-vault_app", got)
+	}
+
+	// An explicit --project-name keeps precedence over the env file
+	m.projectNameFlag = "pinned"
+	m.switchEnvFile(staging)
+	if got := internal.ScopedName("vault_app"); got != "pinned-vault_app" {
+		t.Errorf("with --project-name: ScopedName = %q, want pinned-vault_app", got)
+	}
+}
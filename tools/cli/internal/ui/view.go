@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"leyzenctl/internal"
 )
 
 func (m *Model) View() string {
@@ -29,6 +33,10 @@ func (m *Model) View() string {
 		return m.renderWizardView()
 	case ViewContainerSelection:
 		return m.renderContainerSelectionView()
+	case ViewContainerDetail:
+		return m.renderContainerDetailView()
+	case ViewEnvPicker:
+		return m.renderEnvPickerView()
 	default:
 		return m.renderDashboard()
 	}
@@ -42,18 +50,24 @@ func (m *Model) renderDashboard() string {
 	if m.successMessage != "" {
 		successMsg = m.renderSuccessMessage()
 	}
+	if m.warningMessage != "" {
+		successMsg = m.theme.WarningStatus.Padding(0, 1).Render(m.warningMessage)
+	}
 
 	quitMsg := ""
 	if m.quitConfirm {
 		quitMsg = m.renderQuitConfirmation()
 	}
 
-	help := ""
+	confirmMsg := ""
+	if m.confirmAction != ActionNone {
+		confirmMsg = m.renderActionConfirmation()
+	}
+
 	if m.helpVisible {
-		help = m.renderHelp()
-	} else {
-		help = m.renderHints()
+		return m.renderHelp()
 	}
+	help := m.renderHints()
 
 	footer := m.renderFooter("dashboard")
 
@@ -65,7 +79,13 @@ func (m *Model) renderDashboard() string {
 	if quitMsg != "" {
 		parts = append(parts, quitMsg)
 	}
+	if confirmMsg != "" {
+		parts = append(parts, confirmMsg)
+	}
 	parts = append(parts, status)
+	if m.transitionsVisible {
+		parts = append(parts, m.renderTransitionsPanel())
+	}
 	parts = append(parts, help)
 	parts = append(parts, footer)
 
@@ -73,15 +93,34 @@ func (m *Model) renderDashboard() string {
 	return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, layout)
 }
 
+func (m *Model) renderTransitionsPanel() string {
+	rows := []string{m.theme.Accent.Render("Recent status changes")}
+	if len(m.transitions) == 0 {
+		rows = append(rows, m.theme.Subtitle.Render("No status changes recorded yet."))
+		return m.theme.Pane.Render(strings.Join(rows, "\n"))
+	}
+	// Newest first
+	for i := len(m.transitions) - 1; i >= 0; i-- {
+		tr := m.transitions[i]
+		to := m.formatStatus(ContainerStatus{Status: tr.To, RawStatus: tr.To})
+		rows = append(rows, fmt.Sprintf("%s  %s  %s → %s",
+			m.theme.Subtitle.Render(tr.At.Format("15:04:05")),
+			padRightColored(tr.Name, nameWidth),
+			tr.From,
+			to,
+		))
+	}
+	return m.theme.Pane.Render(strings.Join(rows, "\n"))
+}
+
 func (m *Model) renderLogsView() string {
 	if m.logModeRaw {
-		content := strings.Join(m.logsRaw, "\n")
-		m.viewport.SetContent(content)
+		m.viewport.Width = m.width
+		m.viewport.Height = m.height
+		m.setLogContent(m.logsRaw)
 		if m.viewportYOffsetRaw > 0 {
 			m.viewport.SetYOffset(m.viewportYOffsetRaw)
 		}
-		m.viewport.Width = m.width
-		m.viewport.Height = m.height
 		return m.viewport.View()
 	}
 
@@ -93,7 +132,11 @@ func (m *Model) renderLogsView() string {
 		quitMsg = m.renderQuitConfirmation()
 	}
 
-	footer := m.renderFooter("logs")
+	footer := m.renderFooter(contextLogs)
+	if window := m.logsWindowLabel(); window != "" {
+		footer += m.theme.HelpDesc.Render(" • ") + m.theme.Accent.Render(window)
+	}
+	footer += m.scrollLockBadge()
 
 	var parts []string
 	parts = append(parts, header)
@@ -109,16 +152,14 @@ func (m *Model) renderLogsView() string {
 
 func (m *Model) renderActionView() string {
 	if m.logModeRaw {
-		// Update viewport content to raw logs
-		content := strings.Join(m.logsRaw, "\n")
-		m.viewport.SetContent(content)
+		// Ensure viewport takes full screen, then lay the raw logs out for it
+		m.viewport.Width = m.width
+		m.viewport.Height = m.height
+		m.setLogContent(m.logsRaw)
 		// Restore saved scroll position or go to bottom
 		if m.viewportYOffsetRaw > 0 {
 			m.viewport.SetYOffset(m.viewportYOffsetRaw)
 		}
-		// Ensure viewport takes full screen
-		m.viewport.Width = m.width
-		m.viewport.Height = m.height
 		return m.viewport.View()
 	}
 
@@ -130,7 +171,7 @@ func (m *Model) renderActionView() string {
 		quitMsg = m.renderQuitConfirmation()
 	}
 
-	footer := m.renderFooter("action")
+	footer := m.renderFooter("action") + m.scrollLockBadge()
 
 	var parts []string
 	parts = append(parts, header)
@@ -203,11 +244,7 @@ func (m *Model) buildConfigContent() string {
 	// Show password toggle hint at the top
 	hasPasswords := false
 	for key := range m.configPairs {
-		keyLower := strings.ToLower(key)
-		if strings.Contains(keyLower, "password") ||
-			strings.Contains(keyLower, "secret") ||
-			strings.Contains(keyLower, "pass") ||
-			strings.Contains(keyLower, "token") {
+		if internal.IsSecretKey(key) {
 			hasPasswords = true
 			break
 		}
@@ -231,10 +268,7 @@ func (m *Model) buildConfigContent() string {
 	// Display all variables in alphabetical order
 	for _, key := range keys {
 		value := m.configPairs[key]
-		isPassword := strings.Contains(strings.ToLower(key), "password") ||
-			strings.Contains(strings.ToLower(key), "secret") ||
-			strings.Contains(strings.ToLower(key), "pass") ||
-			strings.Contains(strings.ToLower(key), "token")
+		isPassword := internal.IsSecretKey(key)
 		isVisible := m.configShowPasswords[key]
 
 		// Hide sensitive values (passwords) unless requested
@@ -258,6 +292,22 @@ func (m *Model) renderConfigPanel() string {
 	return ""
 }
 
+// configCategoryOrder lists the categories produced by categorizeConfigPairs in display order.
+var configCategoryOrder = []string{
+	"General",
+	"Authentication & Security",
+	"Vault",
+	"Orchestrator",
+	"PostgreSQL",
+	"Email (SMTP)",
+	"HAProxy/SSL",
+	"Docker Proxy",
+	"CSP",
+	"Proxy",
+	"Development",
+	"Other",
+}
+
 // categorizeConfigPairs organizes variables by logical category
 func (m *Model) categorizeConfigPairs(pairs map[string]string) map[string][]string {
 	categories := make(map[string][]string)
@@ -313,11 +363,12 @@ func (m *Model) categorizeConfigPairs(pairs map[string]string) map[string][]stri
 		"EMAIL_VERIFICATION_EXPIRY_MINUTES": 7,
 	}
 	haproxyOrder := map[string]int{
-		"HTTP_PORT":     0,
-		"HTTPS_PORT":    1,
-		"ENABLE_HTTPS":  2,
-		"SSL_CERT_PATH": 3,
-		"SSL_KEY_PATH":  4,
+		"HTTP_PORT":      0,
+		"HTTPS_PORT":     1,
+		"ENABLE_HTTPS":   2,
+		"SSL_CERT_PATH":  3,
+		"SSL_KEY_PATH":   4,
+		"SSL_CHAIN_PATH": 5,
 	}
 	dockerProxyOrder := map[string]int{
 		"DOCKER_PROXY_URL":       0,
@@ -471,6 +522,13 @@ func (m *Model) renderWizardPanel() string {
 		return m.theme.Pane.Render("No configuration variables found. Use 'c' to view config first.")
 	}
 
+	if m.wizardJumpActive {
+		return m.renderWizardJump()
+	}
+	if m.wizardConfirm {
+		return m.renderWizardSummary()
+	}
+
 	var rows []string
 
 	// Title
@@ -478,21 +536,31 @@ func (m *Model) renderWizardPanel() string {
 
 	// Progress (e.g., "Variable 1 of 10")
 	progress := fmt.Sprintf("Variable %d of %d", m.wizardIndex+1, len(m.wizardFields))
-	rows = append(rows, m.theme.Subtitle.Render(progress))
+	percent := float64(m.wizardIndex+1) / float64(len(m.wizardFields))
+	rows = append(rows, m.wizardProgress.ViewAs(percent)+"  "+m.theme.Subtitle.Render(progress))
 	rows = append(rows, "")
 
 	// Display ONE field at a time
 	field := m.wizardFields[m.wizardIndex]
+	if field.Category != "" {
+		rows = append(rows, m.theme.HelpKey.Render(fmt.Sprintf("── %s ──", field.Category)))
+	}
 	label := field.Key
 	if field.IsPassword {
 		label += " (password)"
 	}
 
 	labelText := m.theme.Accent.Bold(true).Render(fmt.Sprintf("%s:", label))
+	if field.Modified() {
+		labelText += " " + m.theme.WarningStatus.Render("● modified")
+	}
 	rows = append(rows, labelText)
 
-	// Add helpful hint based on field name
-	hint := m.getWizardHint(field.Key)
+	// Prefer the env.template documentation, falling back to the built-in hints
+	hint := field.Summary
+	if hint == "" {
+		hint = m.getWizardHint(field.Key)
+	}
 	if hint != "" {
 		rows = append(rows, m.theme.Subtitle.Render(fmt.Sprintf("[HINT] %s", hint)))
 	}
@@ -509,6 +577,11 @@ func (m *Model) renderWizardPanel() string {
 	rows = append(rows, "")
 
 	rows = append(rows, m.theme.Subtitle.Render("All fields are optional. Leave empty to keep existing value."))
+	if field.Default != "" {
+		rows = append(rows, m.theme.Subtitle.Render(fmt.Sprintf("Default: %s (Ctrl+R to reset)", field.Default)))
+	} else {
+		rows = append(rows, m.theme.Subtitle.Render("No default (empty keeps the current value)"))
+	}
 	rows = append(rows, "")
 
 	if m.wizardError != "" {
@@ -522,10 +595,20 @@ func (m *Model) renderWizardPanel() string {
 func (m *Model) renderHeader() string {
 	spinner := ""
 	if m.actionRunning {
-		spinner = fmt.Sprintf(" %s %s", m.theme.Spinner.Render(m.spinner.View()), m.theme.Accent.Render(strings.ToUpper(string(m.action))))
+		label := strings.ToUpper(string(m.action))
+		if m.buildSteps > 0 {
+			label = fmt.Sprintf("%s %d/%d", label, m.buildStep, m.buildSteps)
+		}
+		spinner = fmt.Sprintf(" %s %s", m.theme.Spinner.Render(m.spinner.View()), m.theme.Accent.Render(label))
 	}
 
-	subtitle := m.theme.Subtitle.Render(fmt.Sprintf("env: %s", m.envFile))
+	subtitle := m.theme.Subtitle.Render(fmt.Sprintf("env: %s · refresh: %s", m.envFile, m.refreshInterval))
+	if m.pollingPaused {
+		subtitle += m.theme.WarningStatus.Render(" · PAUSED")
+	}
+	if internal.DryRun() {
+		subtitle += m.theme.WarningStatus.Render(" · DRY RUN: actions only print their commands")
+	}
 	title := lipgloss.JoinHorizontal(lipgloss.Left,
 		m.theme.Title.Render("Leyzen Vault Control"),
 		spinner,
@@ -540,11 +623,11 @@ const (
 	ageHeader   = "AGE"
 )
 
-// regex to remove ANSI escape sequences
-var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+// regex to remove ANSI escape sequences: colors (SGR) as well as cursor and erase controls
+var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
 
 func visibleLen(s string) int {
-	return len(ansiRegex.ReplaceAllString(s, ""))
+	return utf8.RuneCountInString(ansiRegex.ReplaceAllString(s, ""))
 }
 
 func padRightColored(s string, width int) string {
@@ -569,41 +652,171 @@ func (m *Model) renderStatusPanel() string {
 	}
 
 	var rows []string
-	header := fmt.Sprintf("%s  %s  %s",
+	header := fmt.Sprintf("  %s  %s  %s",
 		padRightColored(m.theme.Accent.Render("NAME"), nameWidth),
 		padRightColored(m.theme.Accent.Render("STATUS"), statusWidth),
 		m.theme.Accent.Render(ageHeader),
 	)
 	rows = append(rows, header)
-	rows = append(rows, fmt.Sprintf("%s  %s  %s",
+	rows = append(rows, fmt.Sprintf("  %s  %s  %s",
 		strings.Repeat("─", nameWidth),
 		strings.Repeat("─", statusWidth),
 		strings.Repeat("─", ageWidth),
 	))
 
-	for _, st := range m.statuses {
+	for i, st := range m.statuses {
 		statusFormatted := m.formatStatus(st)
-		row := fmt.Sprintf("%s  %s  %s",
-			padRightColored(st.Name, nameWidth),
+		marker, name := "  ", st.Name
+		if i == m.statusCursor {
+			marker, name = m.theme.Accent.Render("▸ "), m.theme.Accent.Render(st.Name)
+		}
+		row := fmt.Sprintf("%s%s  %s  %s",
+			marker,
+			padRightColored(name, nameWidth),
 			padRightColored(statusFormatted, statusWidth),
 			st.Age,
 		)
 		rows = append(rows, row)
+		if m.crashDetailsVisible && m.isCrashLooping(st.Name) {
+			rows = append(rows, m.renderCrashDetails(st)...)
+		}
 	}
 
 	return m.theme.Pane.Render(strings.Join(rows, "\n"))
 }
 
+// renderContainerDetailView shows the inspected state of the service selected on the dashboard.
+func (m *Model) renderContainerDetailView() string {
+	var rows []string
+	rows = append(rows, m.theme.Accent.Render("Service "+m.containerDetailName), "")
+
+	switch {
+	case m.containerDetailErr != nil:
+		rows = append(rows, m.theme.ErrorStatus.Render(fmt.Sprintf("Inspect failed: %v", m.containerDetailErr)))
+	case m.containerDetail == nil:
+		rows = append(rows, m.spinner.View()+" Inspecting container...")
+	default:
+		d := m.containerDetail
+		health := d.Health
+		if health == "" {
+			health = "no healthcheck"
+		}
+		ports := "none"
+		if len(d.Ports) > 0 {
+			ports = strings.Join(d.Ports, ", ")
+		}
+		started := "never"
+		if !d.StartedAt.IsZero() {
+			started = d.StartedAt.Local().Format("2006-01-02 15:04:05")
+		}
+		lastError := "none"
+		if d.LastError != "" {
+			lastError = d.LastError
+		}
+		fields := [][2]string{
+			{"Container", d.Name},
+			{"Image", d.Image},
+			{"State", d.State},
+			{"Health", health},
+			{"Started", started},
+			{"Restarts", strconv.Itoa(d.RestartCount)},
+			{"Exit code", strconv.Itoa(d.ExitCode)},
+			{"Ports", ports},
+			{"Last error", lastError},
+		}
+		for _, f := range fields {
+			rows = append(rows, fmt.Sprintf("%s  %s", padRightColored(m.theme.HelpKey.Render(f[0]), 12), f[1]))
+		}
+	}
+
+	var parts []string
+	parts = append(parts, m.renderHeader())
+	if m.quitConfirm {
+		parts = append(parts, m.renderQuitConfirmation())
+	}
+	parts = append(parts, m.theme.Pane.Render(strings.Join(rows, "\n")))
+	parts = append(parts, m.renderFooter(contextContainerDetail))
+
+	layout := lipgloss.JoinVertical(lipgloss.Left, parts...)
+	return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, layout)
+}
+
+// formatStatus colors a status and prefixes a symbol so states differ without color:
+// ✓ running, ✗ failed, ! anything in between.
 func (m *Model) formatStatus(status ContainerStatus) string {
+	if m.isCrashLooping(status.Name) {
+		return m.theme.ErrorStatus.Render("✗ CRASH-LOOPING · " + status.Status)
+	}
+	base, health := splitHealth(status.Status)
 	lower := strings.ToLower(status.RawStatus)
 	switch {
+	case strings.Contains(lower, "exit"), strings.Contains(lower, "dead"):
+		return m.theme.ErrorStatus.Render("✗ " + status.Status)
+	case health == healthUnhealthy:
+		return m.theme.ErrorStatus.Render("✗ "+base) + " " + m.theme.ErrorStatus.Reverse(true).Render(" UNHEALTHY ")
+	case health == healthStarting:
+		return m.theme.WarningStatus.Render("… "+base) + " " + m.theme.WarningStatus.Render("◐ "+startingProgress(status))
+	case health == healthHealthy:
+		return m.theme.ActiveStatus.Render("✓ "+base) + " " + m.theme.ActiveStatus.Render("● healthy")
 	case strings.Contains(lower, "up"):
-		return m.theme.ActiveStatus.Render(status.Status)
-	case strings.Contains(lower, "exit"), strings.Contains(lower, "dead"), strings.Contains(lower, "unhealthy"):
-		return m.theme.ErrorStatus.Render(status.Status)
+		return m.theme.ActiveStatus.Render("✓ " + status.Status)
+	default:
+		return m.theme.WarningStatus.Render("! " + status.Status)
+	}
+}
+
+// startingProgress describes a container whose healthcheck has not passed yet. Failures
+// only count towards unhealthy once the start period is over; before that FailingStreak
+// stays at 0 and the failed probes are taken from the health log instead.
+func startingProgress(status ContainerStatus) string {
+	switch {
+	case status.HealthFailures > 0 && status.HealthRetries > 0:
+		return fmt.Sprintf("starting %d/%d", status.HealthFailures, status.HealthRetries)
+	case status.HealthLogFailures == 1:
+		return "starting · 1 failed check"
+	case status.HealthLogFailures > 1:
+		return fmt.Sprintf("starting · %d failed checks", status.HealthLogFailures)
 	default:
-		return m.theme.WarningStatus.Render(status.Status)
+		return "starting"
+	}
+}
+
+// Health states docker appends to the status of a container with a healthcheck.
+const (
+	healthStarting  = "starting"
+	healthHealthy   = "healthy"
+	healthUnhealthy = "unhealthy"
+)
+
+var healthSuffixRegex = regexp.MustCompile(`\s*\((healthy|unhealthy|health: starting)\)$`)
+
+// splitHealth separates the health suffix from a docker status: "Up 5 minutes (healthy)"
+// gives "Up 5 minutes" and "healthy". The health is empty without a healthcheck.
+func splitHealth(status string) (string, string) {
+	match := healthSuffixRegex.FindStringSubmatchIndex(status)
+	if match == nil {
+		return status, ""
 	}
+	health := status[match[2]:match[3]]
+	if health == "health: starting" {
+		health = healthStarting
+	}
+	return status[:match[0]], health
+}
+
+// renderCrashDetails lists the restart count, last exit code and latest log lines of a
+// crash-looping service, indented under its status row.
+func (m *Model) renderCrashDetails(st ContainerStatus) []string {
+	indent := strings.Repeat(" ", 4)
+	rows := []string{m.theme.ErrorStatus.Render(fmt.Sprintf("%s↳ restarts: %d · last exit code: %d", indent, st.RestartCount, st.ExitCode))}
+	lines := m.crashLogs[st.Name]
+	if len(lines) == 0 {
+		return append(rows, m.theme.HelpDesc.Render(indent+"  (no log output yet)"))
+	}
+	for _, line := range lines {
+		rows = append(rows, m.theme.HelpDesc.Render(indent+"  "+line))
+	}
+	return rows
 }
 
 func (m *Model) renderLogPanel() string {
@@ -630,97 +843,188 @@ func (m *Model) renderQuitConfirmation() string {
 		Render(message)
 }
 
+func (m *Model) renderActionConfirmation() string {
+	message := fmt.Sprintf(
+		"\nStop all services? This runs docker compose down --remove-orphans. Press %s, %s or %s again to confirm, or any other key to cancel",
+		m.theme.HelpKey.Render("Y"),
+		m.theme.HelpKey.Render("Enter"),
+		m.theme.HelpKey.Render("S"),
+	)
+	return m.theme.WarningStatus.
+		Padding(0, 2).
+		MarginBottom(1).
+		Render(message)
+}
+
 func (m *Model) renderSuccessMessage() string {
 	return m.theme.SuccessStatus.Padding(0, 1).Render(m.successMessage)
 }
 
 func (m *Model) renderFooter(context string) string {
-	var hints []string
-
-	switch context {
-	case "dashboard":
-		hints = []string{
-			fmt.Sprintf("%s Quit", m.theme.HelpKey.Render("Ctrl+C")),
-			fmt.Sprintf("%s Start", m.theme.HelpKey.Render("a")),
-			fmt.Sprintf("%s Restart", m.theme.HelpKey.Render("r")),
-			fmt.Sprintf("%s Stop", m.theme.HelpKey.Render("s")),
-			fmt.Sprintf("%s Rebuild", m.theme.HelpKey.Render("b")),
-			fmt.Sprintf("%s Config", m.theme.HelpKey.Render("c")),
-			fmt.Sprintf("%s Wizard", m.theme.HelpKey.Render("w")),
-			fmt.Sprintf("%s Logs", m.theme.HelpKey.Render("l")),
-			fmt.Sprintf("%s Help", m.theme.HelpKey.Render("?")),
-		}
-	case "config":
-		hints = []string{
-			fmt.Sprintf("%s Back", m.theme.HelpKey.Render("Esc")),
-			fmt.Sprintf("%s Quit", m.theme.HelpKey.Render("Ctrl+C")),
-			fmt.Sprintf("%s Refresh", m.theme.HelpKey.Render("r")),
-			fmt.Sprintf("%s Scroll", m.theme.HelpKey.Render("↑/↓")),
-			fmt.Sprintf("%s Toggle passwords", m.theme.HelpKey.Render("Space")),
+	bindings := bindingsFor(context)
+	if len(bindings) == 0 {
+		// Views without their own hints only offer Quit, the first global binding
+		bindings = keymap[:1]
+	}
+	return renderHintsFooter(m.theme, bindings)
+}
+
+// renderHintsFooter renders the footer hints of bindings, shared by the dashboard and
+// the top view.
+func renderHintsFooter(theme Theme, bindings []keyBinding) string {
+	hints := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		hints = append(hints, fmt.Sprintf("%s %s", theme.HelpKey.Render(b.Key), b.Short))
+	}
+
+	separator := theme.HelpDesc.Render(" • ")
+	return theme.Footer.Render(strings.Join(hints, separator))
+}
+
+// scrollLockBadge flags a locked logs viewport in the footer.
+func (m *Model) scrollLockBadge() string {
+	if !m.scrollLocked {
+		return ""
+	}
+	return m.theme.HelpDesc.Render(" • ") + m.theme.WarningStatus.Render("SCROLL LOCKED")
+}
+
+// logsWindowLabel describes the --since window of the logs view for the footer.
+func (m *Model) logsWindowLabel() string {
+	switch {
+	case m.logsSinceInput:
+		return "Since (e.g. 10m, 2h; empty for history): " + m.logsSinceQuery + "█"
+	case m.logsSince != "":
+		return "Window: last " + m.logsSince
+	}
+	return ""
+}
+
+func (m *Model) renderHints() string {
+	return ""
+}
+
+// renderHelp renders the full-screen cheatsheet, filtered by the search query.
+func (m *Model) renderHelp() string {
+	var rows []string
+	search := "Press / to search"
+	if m.helpSearching || m.helpQuery != "" {
+		search = "Search: " + m.helpQuery
+		if m.helpSearching {
+			search += "█"
 		}
-	case "wizard":
-		hints = []string{
-			fmt.Sprintf("%s Previous", m.theme.HelpKey.Render("←")),
-			fmt.Sprintf("%s Next", m.theme.HelpKey.Render("→")),
-			fmt.Sprintf("%s Save", m.theme.HelpKey.Render("Ctrl+S")),
-			fmt.Sprintf("%s Cancel", m.theme.HelpKey.Render("Esc")),
-			fmt.Sprintf("%s Quit", m.theme.HelpKey.Render("Ctrl+C")),
+	}
+	rows = append(rows, m.theme.Subtitle.Render(search), "")
+
+	group := ""
+	found := false
+	for _, b := range keymap {
+		if !b.matches(m.helpQuery) {
+			continue
 		}
-	case "logs":
-		hints = []string{
-			fmt.Sprintf("%s Back", m.theme.HelpKey.Render("Esc")),
-			fmt.Sprintf("%s Quit", m.theme.HelpKey.Render("Ctrl+C")),
-			fmt.Sprintf("%s Scroll", m.theme.HelpKey.Render("↑/↓")),
-			fmt.Sprintf("%s Raw view", m.theme.HelpKey.Render("v")),
+		if b.Group != group {
+			if group != "" {
+				rows = append(rows, "")
+			}
+			group = b.Group
+			rows = append(rows, m.theme.Accent.Render(group+":"))
 		}
-	case "action":
-		hints = []string{
-			fmt.Sprintf("%s Back (wait for completion)", m.theme.HelpKey.Render("Esc")),
-			fmt.Sprintf("%s Quit", m.theme.HelpKey.Render("Ctrl+C")),
-			fmt.Sprintf("%s Scroll", m.theme.HelpKey.Render("↑/↓")),
-			fmt.Sprintf("%s Raw view", m.theme.HelpKey.Render("v")),
+		rows = append(rows, fmt.Sprintf("%s %s", m.theme.HelpKey.Render(fmt.Sprintf("%-10s", b.Key)), b.Help))
+		found = true
+	}
+	if !found {
+		rows = append(rows, m.theme.Subtitle.Render("No matching keys."))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
+	layout := lipgloss.JoinVertical(lipgloss.Left,
+		m.theme.Title.Render("Keyboard shortcuts"),
+		m.theme.Pane.Render(content),
+		m.renderFooter(contextHelp),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, layout)
+}
+
+// wizardSummaryLimit caps the changed keys listed before saving.
+const wizardSummaryLimit = 15
+
+// renderWizardSummary lists the fields whose values differ from the loaded configuration.
+func (m *Model) renderWizardSummary() string {
+	var changed []WizardField
+	for _, field := range m.wizardFields {
+		if field.Changed() {
+			changed = append(changed, field)
 		}
-	case "container-selection":
-		hints = []string{
-			fmt.Sprintf("%s Select/Deselect", m.theme.HelpKey.Render("Space")),
-			fmt.Sprintf("%s Confirm", m.theme.HelpKey.Render("Enter")),
-			fmt.Sprintf("%s Cancel", m.theme.HelpKey.Render("Esc")),
-			fmt.Sprintf("%s Navigate", m.theme.HelpKey.Render("↑/↓")),
-			fmt.Sprintf("%s Quit", m.theme.HelpKey.Render("Ctrl+C")),
+	}
+
+	var rows []string
+	rows = append(rows, m.theme.Accent.Render("Review changes"))
+	rows = append(rows, m.theme.Subtitle.Render(fmt.Sprintf("%d of %d variables changed", len(changed), len(m.wizardFields))))
+	rows = append(rows, "")
+
+	if len(changed) == 0 {
+		rows = append(rows, m.theme.Subtitle.Render("No values changed."))
+	}
+	for i, field := range changed {
+		if i == wizardSummaryLimit {
+			rows = append(rows, m.theme.Subtitle.Render(fmt.Sprintf("... and %d more", len(changed)-wizardSummaryLimit)))
+			break
 		}
-	default:
-		hints = []string{
-			fmt.Sprintf("%s Quit", m.theme.HelpKey.Render("Ctrl+C")),
+		from, to := field.Value, field.Input.Value()
+		if field.IsPassword {
+			from, to = maskSecret(from), maskSecret(to)
 		}
+		rows = append(rows, fmt.Sprintf("%s  %s → %s", m.theme.HelpKey.Render(field.Key), from, m.theme.SuccessStatus.Render(to)))
 	}
+	rows = append(rows, "")
+	rows = append(rows, m.theme.Subtitle.Render("Empty fields keep their current value. A .bak copy of the env file is kept unless LEYZEN_ENV_BACKUPS=0."))
+	rows = append(rows, m.theme.Subtitle.Render("ENTER to save, ESC to continue editing"))
 
-	separator := m.theme.HelpDesc.Render(" • ")
-	return m.theme.Footer.Render(strings.Join(hints, separator))
+	return m.theme.Pane.Render(strings.Join(rows, "\n"))
 }
 
-func (m *Model) renderHints() string {
-	return ""
+// maskSecret hides a secret value in the change summary.
+func maskSecret(value string) string {
+	if value == "" {
+		return "(empty)"
+	}
+	return strings.Repeat("•", utf8.RuneCountInString(value))
 }
 
-func (m *Model) renderHelp() string {
-	rows := []string{
-		m.theme.Accent.Render("Actions:"),
-		fmt.Sprintf("%s Quit the dashboard (press twice to confirm)", m.theme.HelpKey.Render("Ctrl+C")),
-		fmt.Sprintf("%s Start the stack (docker compose up)", m.theme.HelpKey.Render("a")),
-		fmt.Sprintf("%s Restart the stack", m.theme.HelpKey.Render("r")),
-		fmt.Sprintf("%s Stop the stack", m.theme.HelpKey.Render("s")),
-		fmt.Sprintf("%s Rebuild configuration", m.theme.HelpKey.Render("b")),
-		fmt.Sprintf("%s Toggle this help overlay", m.theme.HelpKey.Render("?")),
-		"",
-		m.theme.Accent.Render("Navigation:"),
-		fmt.Sprintf("%s Return to dashboard", m.theme.HelpKey.Render("Esc")),
-		fmt.Sprintf("%s View logs", m.theme.HelpKey.Render("l")),
-		fmt.Sprintf("%s View configuration", m.theme.HelpKey.Render("c")),
-		fmt.Sprintf("%s Run wizard", m.theme.HelpKey.Render("w")),
-		fmt.Sprintf("%s Scroll logs/config", m.theme.HelpKey.Render("↑/↓")),
+// wizardJumpListLimit caps the fields listed in the wizard jump filter.
+const wizardJumpListLimit = 12
+
+// renderWizardJump renders the field filter opened with Ctrl+F in the wizard.
+func (m *Model) renderWizardJump() string {
+	matches := m.wizardJumpMatches()
+
+	var rows []string
+	rows = append(rows, m.theme.Accent.Render("Jump to variable"))
+	rows = append(rows, m.theme.Subtitle.Render(fmt.Sprintf("%d of %d variables match", len(matches), len(m.wizardFields))))
+	rows = append(rows, "")
+	rows = append(rows, "Filter: "+m.wizardJumpQuery+"█")
+	rows = append(rows, "")
+
+	// Keep the highlighted entry visible when the list is longer than the limit
+	start := 0
+	if m.wizardJumpCursor >= wizardJumpListLimit {
+		start = m.wizardJumpCursor - wizardJumpListLimit + 1
 	}
-	content := lipgloss.JoinVertical(lipgloss.Left, rows...)
-	return lipgloss.NewStyle().MarginTop(1).Render(m.theme.Pane.Render(content))
+	for i := start; i < len(matches) && i < start+wizardJumpListLimit; i++ {
+		key := m.wizardFields[matches[i]].Key
+		if i == m.wizardJumpCursor {
+			rows = append(rows, m.theme.HelpKey.Render("> "+key))
+		} else {
+			rows = append(rows, "  "+key)
+		}
+	}
+	if len(matches) == 0 {
+		rows = append(rows, m.theme.Subtitle.Render("No matching variables."))
+	}
+	rows = append(rows, "")
+	rows = append(rows, m.theme.Subtitle.Render("↑/↓ to choose, ENTER to jump, ESC to return to the current field"))
+
+	return m.theme.Pane.Render(strings.Join(rows, "\n"))
 }
 
 // getWizardHint returns a helpful hint for a configuration field
@@ -743,15 +1047,32 @@ func (m *Model) getWizardHint(key string) string {
 	return ""
 }
 
+// containerSelectionIntro returns the rows rendered above the container list.
+func (m *Model) containerSelectionIntro() []string {
+	actionName := strings.ToUpper(string(m.pendingAction))
+	return []string{
+		m.theme.Accent.Render(fmt.Sprintf("Select containers for %s action", actionName)),
+		"",
+		m.theme.Subtitle.Render("Use SPACE or click to select/deselect, ENTER to confirm, ESC to cancel"),
+		"",
+	}
+}
+
+// containerListTop returns the screen row of the first item in the container selection
+// view: header, optional quit prompt, the pane's top border and padding, then the intro rows.
+func (m *Model) containerListTop() int {
+	top := lipgloss.Height(m.renderHeader())
+	if m.quitConfirm {
+		top += lipgloss.Height(m.renderQuitConfirmation())
+	}
+	top += m.theme.Pane.GetBorderTopSize() + m.theme.Pane.GetPaddingTop()
+	return top + len(m.containerSelectionIntro())
+}
+
 func (m *Model) renderContainerSelectionView() string {
 	header := m.renderHeader()
 
-	var rows []string
-	actionName := strings.ToUpper(string(m.pendingAction))
-	rows = append(rows, m.theme.Accent.Render(fmt.Sprintf("Select containers for %s action", actionName)))
-	rows = append(rows, "")
-	rows = append(rows, m.theme.Subtitle.Render("Use SPACE to select/deselect, ENTER to confirm, ESC to cancel"))
-	rows = append(rows, "")
+	rows := m.containerSelectionIntro()
 
 	var items []string
 	for i, item := range m.containerItems {
@@ -0,0 +1,21 @@
+package ui
+
+import "testing"
+
+func TestStartingProgressCountsStartPeriodFailures(t *testing.T) {
+	tests := []struct {
+		status ContainerStatus
+		want   string
+	}{
+		{ContainerStatus{}, "starting"},
+		// During the start period FailingStreak stays at 0 while probes keep failing
+		{ContainerStatus{HealthRetries: 3, HealthLogFailures: 2}, "starting · 2 failed checks"},
+		{ContainerStatus{HealthRetries: 3, HealthLogFailures: 1}, "starting · 1 failed check"},
+		{ContainerStatus{HealthFailures: 2, HealthRetries: 3, HealthLogFailures: 2}, "starting 2/3"},
+	}
+	for _, tt := range tests {
+		if got := startingProgress(tt.status); got != tt.want {
+			t.Errorf("startingProgress(%+v) = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 )
@@ -17,7 +18,7 @@ var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
 
 // VisibleLen returns the length of a string excluding ANSI escape sequences.
 func VisibleLen(s string) int {
-	return len(ansiRegex.ReplaceAllString(s, ""))
+	return utf8.RuneCountInString(ansiRegex.ReplaceAllString(s, ""))
 }
 
 // PadRightVisible pads a string with spaces to the right, accounting for invisible ANSI sequences.
@@ -36,16 +37,17 @@ func ensureBinaryAvailable(name string) error {
 	return nil
 }
 
-// FormatStatusColor returns a colored version of the Docker container status.
+// FormatStatusColor returns a colored version of the Docker container status,
+// prefixed with ✓, ✗ or ! so the state is readable without color.
 func FormatStatusColor(status string) string {
 	lower := strings.ToLower(status)
 	switch {
+	case strings.Contains(lower, "exit") || strings.Contains(lower, "dead") || strings.Contains(lower, "unhealthy"):
+		return color.HiRedString("✗ " + status)
 	case strings.Contains(lower, "up"):
-		return color.HiGreenString(status)
-	case strings.Contains(lower, "exit") || strings.Contains(lower, "dead"):
-		return color.HiRedString(status)
+		return color.HiGreenString("✓ " + status)
 	default:
-		return color.HiYellowString(status)
+		return color.HiYellowString("! " + status)
 	}
 }
 
@@ -54,6 +56,20 @@ func FormatKeyValue(key, value string) string {
 	return fmt.Sprintf("%s: %s", color.HiBlueString(key), value)
 }
 
+// FormatBytes renders a byte count with a binary unit, e.g. "12.3 MiB".
+func FormatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
 // RunBuildScript executes the internal Go generator to rebuild HAProxy and Compose configuration.
 func RunBuildScript(envFile string) error {
 	return GenerateConfig(os.Stdout, os.Stderr, envFile)
@@ -99,3 +115,50 @@ func EnsureDockerGeneratedFileWithWriter(stdout, stderr io.Writer, envFile strin
 
 	return nil
 }
+
+// maxSuggestions caps the names listed by closestMatches.
+const maxSuggestions = 3
+
+// closestMatches returns the candidates nearest to name by edit distance, skipping those
+// too far off to be a plausible typo.
+func closestMatches(name string, candidates []string) []string {
+	limit := max(2, utf8.RuneCountInString(name)/3)
+	best := limit + 1
+	var matches []string
+	for _, candidate := range candidates {
+		distance := levenshtein(name, candidate)
+		switch {
+		case distance < best:
+			best = distance
+			matches = []string{candidate}
+		case distance == best:
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) > maxSuggestions {
+		matches = matches[:maxSuggestions]
+	}
+	return matches
+}
+
+// levenshtein returns the number of single-rune edits needed to turn a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -1,29 +1,93 @@
 package internal
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"leyzenctl/internal/compose"
 )
 
 const (
 	// Minimum secret length required for cryptographic secrets
 	minSecretLength = 32
+
+	// Defaults mirrored from env.template and the compose builder
+	defaultMaxFileSizeMB  = 100
+	defaultMaxTotalSizeMB = 1024
 )
 
 type valueValidator func(string) (string, error)
 
-var keyValidators = map[string]valueValidator{
-	"WEB_REPLICAS":      validatePositiveInt,
-	"ORCH_PASS":         validatePassword,
-	"ROTATION_INTERVAL": validatePositiveInt,
-	"SECRET_KEY":        validateSecretLength,
+// typeValidators maps the types declared with "# @type:" in env.template to their validator.
+var typeValidators = map[string]valueValidator{
+	"int":          validateInt,
+	"positive_int": validatePositiveInt,
+	"bool":         validateBool,
+	"port":         validatePort,
+	"secret":       validateSecretLength,
+	"memory":       validateMemLimit,
+	"cpus":         validateCPULimit,
+	"duration":     validateDuration,
+}
+
+// builtinEnvTypes types the security-critical variables even when env.template is
+// missing or lost their annotation, so a short SECRET_KEY is never accepted.
+var builtinEnvTypes = map[string]string{
+	"SECRET_KEY": "secret",
+}
+
+var (
+	envTypesMu sync.Mutex
+	envTypes   = make(map[string]map[string]string)
+)
+
+// declaredEnvType returns the type of key for envFile: the one annotated in the
+// env.template next to it (see FindEnvTemplatePath), else the built-in type, else "".
+func declaredEnvType(envFile, key string) string {
+	if typ := templateEnvTypes(envFile)[key]; typ != "" {
+		return typ
+	}
+	return builtinEnvTypes[key]
 }
 
-// ValidateEnvValue validates and sanitizes a value for the given key.
-func ValidateEnvValue(key, value string) (string, error) {
+// templateEnvTypes returns the types annotated in the env.template of envFile, cached
+// per template. It returns nil when the template cannot be found.
+func templateEnvTypes(envFile string) map[string]string {
+	templatePath, err := FindEnvTemplatePath(envFile)
+	if err != nil {
+		return nil
+	}
+
+	envTypesMu.Lock()
+	defer envTypesMu.Unlock()
+	if types, ok := envTypes[templatePath]; ok {
+		return types
+	}
+	types := make(map[string]string)
+	if docs, err := loadTemplateDocumentation(templatePath); err == nil {
+		for key, doc := range docs {
+			if doc.Type != "" {
+				types[key] = doc.Type
+			}
+		}
+	}
+	envTypes[templatePath] = types
+	return types
+}
+
+// ValidateEnvValue validates and sanitizes a value for the given key of envFile, using
+// the type declared for it in that file's env.template. Untyped variables and unknown
+// types are accepted as is.
+func ValidateEnvValue(envFile, key, value string) (string, error) {
 	trimmed := strings.TrimSpace(value)
-	if validator, ok := keyValidators[key]; ok {
+	if validator, ok := typeValidators[declaredEnvType(envFile, key)]; ok {
 		if trimmed == "" {
 			return "", nil
 		}
@@ -32,8 +96,12 @@ func ValidateEnvValue(key, value string) (string, error) {
 	return trimmed, nil
 }
 
-func validateNonEmpty(value string) (string, error) {
-	return strings.TrimSpace(value), nil
+func validateInt(value string) (string, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return "", fmt.Errorf("value must be an integer")
+	}
+	return strconv.Itoa(n), nil
 }
 
 func validatePositiveInt(value string) (string, error) {
@@ -48,8 +116,50 @@ func validatePositiveInt(value string) (string, error) {
 	return strconv.Itoa(n), nil
 }
 
-func validatePassword(value string) (string, error) {
-	return strings.TrimSpace(value), nil
+func validateMemLimit(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if !compose.MemLimitPattern.MatchString(trimmed) {
+		return "", fmt.Errorf("value must be a memory size such as 512m or 2g")
+	}
+	return strings.ToLower(trimmed), nil
+}
+
+func validateCPULimit(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	n, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil || n <= 0 {
+		return "", fmt.Errorf("value must be a positive number of CPUs such as 0.5 or 2")
+	}
+	return trimmed, nil
+}
+
+func validateBool(value string) (string, error) {
+	lower := strings.ToLower(strings.TrimSpace(value))
+	switch lower {
+	case "true", "false", "1", "0", "yes", "no", "on", "off":
+		return lower, nil
+	}
+	return "", fmt.Errorf("value must be true or false (1/0, yes/no and on/off are accepted)")
+}
+
+func validatePort(value string) (string, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 1 || n > 65535 {
+		return "", fmt.Errorf("value must be a port between 1 and 65535")
+	}
+	return strconv.Itoa(n), nil
+}
+
+// validateDuration accepts Go/compose durations and, like the compose builder, bare seconds.
+func validateDuration(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	if n, err := strconv.Atoi(trimmed); err == nil && n >= 0 {
+		return trimmed, nil
+	}
+	if d, err := time.ParseDuration(trimmed); err != nil || d < 0 {
+		return "", fmt.Errorf("value must be a duration such as 30s or 2m")
+	}
+	return trimmed, nil
 }
 
 // validateSecretLength validates that a cryptographic secret meets minimum length requirements.
@@ -64,14 +174,91 @@ func validateSecretLength(value string) (string, error) {
 	return trimmed, nil
 }
 
+// GenerateSecret returns a random hex secret twice as long as minSecretLength,
+// matching `openssl rand -hex 32`.
+func GenerateSecret() (string, error) {
+	buf := make([]byte, minSecretLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// ValidateSizeLimits checks that the per-file upload limit fits in the total tmpfs size.
+// Missing values fall back to their defaults.
+func ValidateSizeLimits(env map[string]string) error {
+	fileSize, err := sizeLimit(env, "VAULT_MAX_FILE_SIZE_MB", defaultMaxFileSizeMB)
+	if err != nil {
+		return err
+	}
+	totalSize, err := sizeLimit(env, "VAULT_MAX_TOTAL_SIZE_MB", defaultMaxTotalSizeMB)
+	if err != nil {
+		return err
+	}
+	if fileSize > totalSize {
+		return fmt.Errorf("VAULT_MAX_FILE_SIZE_MB (%d) must not exceed VAULT_MAX_TOTAL_SIZE_MB (%d)", fileSize, totalSize)
+	}
+	return nil
+}
+
+func sizeLimit(env map[string]string, key string, def int) (int, error) {
+	raw := strings.TrimSpace(env[key])
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer (got %q)", key, raw)
+	}
+	return n, nil
+}
+
+// PreflightEnv checks that the env file is usable before the dashboard starts.
+// It returns an error when env.template cannot be found, and a list of problems
+// that the setup wizard can fix (missing file, missing or weak secrets).
+func PreflightEnv(envFilePath string) ([]string, error) {
+	if _, err := FindEnvTemplatePath(envFilePath); err != nil {
+		return nil, fmt.Errorf("env.template not found next to %s: %w", envFilePath, err)
+	}
+
+	if _, err := os.Stat(envFilePath); errors.Is(err, os.ErrNotExist) {
+		return []string{fmt.Sprintf("%s does not exist", envFilePath)}, nil
+	}
+
+	envFile, err := LoadEnvFile(envFilePath)
+	if err != nil {
+		return nil, err
+	}
+	pairs, err := envFile.DecryptedPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	for _, key := range []string{"SECRET_KEY", "POSTGRES_PASSWORD"} {
+		value := strings.TrimSpace(pairs[key])
+		if value == "" {
+			problems = append(problems, fmt.Sprintf("%s is missing or empty", key))
+			continue
+		}
+		if _, err := ValidateEnvValue(envFilePath, key, value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", key, err))
+		}
+	}
+	if err := ValidateSizeLimits(pairs); err != nil {
+		problems = append(problems, err.Error())
+	}
+	return problems, nil
+}
+
 // SurveyValidator wraps ValidateEnvValue for use with survey prompts.
-func SurveyValidator(key string) func(interface{}) error {
+func SurveyValidator(envFile, key string) func(interface{}) error {
 	return func(ans interface{}) error {
 		str, ok := ans.(string)
 		if !ok {
 			return fmt.Errorf("unexpected answer type")
 		}
-		_, err := ValidateEnvValue(key, str)
+		_, err := ValidateEnvValue(envFile, key, str)
 		return err
 	}
 }
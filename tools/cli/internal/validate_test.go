@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateSecret(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 5; i++ {
+		secret, err := GenerateSecret()
+		if err != nil {
+			t.Fatalf("GenerateSecret: %v", err)
+		}
+		if len(secret) != 2*minSecretLength {
+			t.Errorf("len(%q) = %d, want %d", secret, len(secret), 2*minSecretLength)
+		}
+		if strings.Trim(secret, "0123456789abcdef") != "" {
+			t.Errorf("%q is not lowercase hex", secret)
+		}
+		if seen[secret] {
+			t.Errorf("GenerateSecret returned %q twice", secret)
+		}
+		seen[secret] = true
+
+		if _, err := validateSecretLength(secret); err != nil {
+			t.Errorf("generated secret rejected: %v", err)
+		}
+	}
+}
+
+func TestValidateSecretLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: ""},
+		{name: "whitespace only", value: "   ", want: ""},
+		{name: "too short", value: strings.Repeat("a", minSecretLength-1), wantErr: true},
+		{name: "minimum length", value: strings.Repeat("a", minSecretLength), want: strings.Repeat("a", minSecretLength)},
+		{name: "trimmed before counting", value: "  " + strings.Repeat("b", minSecretLength) + "\t", want: strings.Repeat("b", minSecretLength)},
+		{name: "padding does not count", value: " " + strings.Repeat("c", minSecretLength-1) + " ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateSecretLength(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateSecretLength(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("validateSecretLength(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateIntTypes(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator valueValidator
+		value     string
+		want      string
+		wantErr   bool
+	}{
+		{name: "int accepts negatives", validator: validateInt, value: "-5", want: "-5"},
+		{name: "int accepts zero", validator: validateInt, value: "0", want: "0"},
+		{name: "int normalizes", validator: validateInt, value: " +7 ", want: "7"},
+		{name: "int rejects text", validator: validateInt, value: "seven", wantErr: true},
+		{name: "positive_int rejects negatives", validator: validatePositiveInt, value: "-5", wantErr: true},
+		{name: "positive_int rejects zero", validator: validatePositiveInt, value: "0", wantErr: true},
+		{name: "positive_int accepts one", validator: validatePositiveInt, value: "1", want: "1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.validator(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validator(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("validator(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// writeRepoFile writes content to name under dir, creating parent directories.
+func writeRepoFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// chdirRepo makes a temporary directory look like the repository root, with rootTemplate
+// as its env.template, and changes into it for the duration of the test.
+func chdirRepo(t *testing.T, rootTemplate string) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeRepoFile(t, dir, "leyzenctl", "")
+	writeRepoFile(t, dir, "env.template", rootTemplate)
+	t.Chdir(dir)
+	return dir
+}
+
+func TestValidateEnvValueUsesTemplateOfEnvFile(t *testing.T) {
+	root := chdirRepo(t, "# @type: port\nAPP_PORT=\n")
+	typed := writeRepoFile(t, root, ".env", "")
+	writeRepoFile(t, root, "staging/env.template", "APP_PORT=\n")
+	untyped := writeRepoFile(t, root, "staging/.env", "")
+
+	if _, err := ValidateEnvValue(typed, "APP_PORT", "not-a-port"); err == nil {
+		t.Error("APP_PORT accepted a non-numeric value although its template types it as a port")
+	}
+	if _, err := ValidateEnvValue(untyped, "APP_PORT", "not-a-port"); err != nil {
+		t.Errorf("APP_PORT is untyped in the staging template but was rejected: %v", err)
+	}
+}
+
+func TestValidateEnvValueKeepsBuiltinSecretCheck(t *testing.T) {
+	root := chdirRepo(t, "SECRET_KEY=\n")
+	unannotated := writeRepoFile(t, root, ".env", "")
+	// Neither this directory nor its parent holds an env.template
+	noTemplate := writeRepoFile(t, root, "stacks/prod/.env", "")
+
+	for _, envFile := range []string{unannotated, noTemplate} {
+		if _, err := ValidateEnvValue(envFile, "SECRET_KEY", "short"); err == nil {
+			t.Errorf("%s: short SECRET_KEY accepted", envFile)
+		}
+		if _, err := ValidateEnvValue(envFile, "SECRET_KEY", strings.Repeat("k", minSecretLength)); err != nil {
+			t.Errorf("%s: valid SECRET_KEY rejected: %v", envFile, err)
+		}
+	}
+}